@@ -0,0 +1,188 @@
+package sqldblogger
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// DropPolicy is asynchronous logger behaviour when its buffer is full (see: WithAsyncLogger).
+type DropPolicy uint8
+
+const (
+	// DropPolicyBlock will block the caller until buffer has free space, no log will be dropped.
+	DropPolicyBlock DropPolicy = iota
+	// DropPolicyDropNewest will drop the log being delivered.
+	DropPolicyDropNewest
+	// DropPolicyDropOldest will drop the oldest log in buffer to make space for the log being delivered.
+	DropPolicyDropOldest
+)
+
+// asyncLogger deliver log to wrapped Logger from a background worker via bounded queue.
+type asyncLogger struct {
+	logger Logger
+	// events is true if wrapped Logger implements EventLogger.
+	events  bool
+	policy  DropPolicy
+	queue   chan asyncEntry
+	done    chan struct{}
+	dropped uint64
+	// mu guard queue from being closed while sending.
+	mu     sync.RWMutex
+	closed bool
+}
+
+// asyncEntry is a queued log, or a flush marker if flushed is not nil.
+type asyncEntry struct {
+	ctx     context.Context
+	level   Level
+	msg     string
+	data    map[string]interface{}
+	event   *Event
+	flushed chan struct{}
+}
+
+func newAsyncLogger(lg Logger, bufferSize int, policy DropPolicy) *asyncLogger {
+	_, events := lg.(EventLogger)
+	a := &asyncLogger{
+		logger: lg,
+		events: events,
+		policy: policy,
+		queue:  make(chan asyncEntry, bufferSize),
+		done:   make(chan struct{}),
+	}
+
+	go a.work()
+
+	return a
+}
+
+// Log implement Logger, it will deliver log synchronously after Close().
+func (a *asyncLogger) Log(ctx context.Context, level Level, msg string, data map[string]interface{}) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	a.enqueue(asyncEntry{ctx: ctx, level: level, msg: msg, data: data})
+}
+
+// LogEvent implement EventLogger, the event is cloned so it can be delivered later.
+// It must be called only if wrapped Logger implements EventLogger.
+func (a *asyncLogger) LogEvent(ctx context.Context, e *Event) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	a.enqueue(asyncEntry{ctx: ctx, event: e.Clone()})
+}
+
+// enqueue deliver given log to the queue according to drop policy, or synchronously after Close().
+// Caller must hold read lock.
+func (a *asyncLogger) enqueue(e asyncEntry) {
+	if a.closed {
+		a.deliver(e)
+		return
+	}
+
+	switch a.policy {
+	case DropPolicyDropNewest:
+		select {
+		case a.queue <- e:
+		default:
+			atomic.AddUint64(&a.dropped, 1)
+		}
+	case DropPolicyDropOldest:
+		for {
+			select {
+			case a.queue <- e:
+				return
+			default:
+			}
+
+			select {
+			case old := <-a.queue:
+				a.drop(old)
+			default:
+			}
+		}
+	default:
+		a.queue <- e
+	}
+}
+
+// Flush wait until all queued logs before this call delivered, or given context done.
+func (a *asyncLogger) Flush(ctx context.Context) error {
+	a.mu.RLock()
+
+	if a.closed {
+		a.mu.RUnlock()
+		return nil
+	}
+
+	flushed := make(chan struct{})
+	select {
+	case a.queue <- asyncEntry{flushed: flushed}:
+		a.mu.RUnlock()
+	case <-ctx.Done():
+		a.mu.RUnlock()
+		return ctx.Err()
+	}
+
+	select {
+	case <-flushed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stop accepting new log and wait until all queued logs delivered.
+func (a *asyncLogger) Close() error {
+	a.mu.Lock()
+
+	if !a.closed {
+		a.closed = true
+		close(a.queue)
+	}
+
+	a.mu.Unlock()
+	<-a.done
+
+	return nil
+}
+
+// Dropped return total dropped logs.
+func (a *asyncLogger) Dropped() uint64 {
+	return atomic.LoadUint64(&a.dropped)
+}
+
+// drop count dropped log, a dropped flush marker is considered flushed because every log before it was delivered.
+func (a *asyncLogger) drop(e asyncEntry) {
+	if e.flushed != nil {
+		close(e.flushed)
+		return
+	}
+
+	atomic.AddUint64(&a.dropped, 1)
+}
+
+func (a *asyncLogger) work() {
+	defer close(a.done)
+
+	for e := range a.queue {
+		if e.flushed != nil {
+			close(e.flushed)
+			continue
+		}
+
+		a.deliver(e)
+	}
+}
+
+// deliver given log to wrapped Logger.
+func (a *asyncLogger) deliver(e asyncEntry) {
+	if e.event != nil {
+		a.logger.(EventLogger).LogEvent(e.ctx, e.event)
+		return
+	}
+
+	a.logger.Log(e.ctx, e.level, e.msg, e.data)
+}
@@ -0,0 +1,117 @@
+package sqldblogger
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAsyncLogger_Log(t *testing.T) {
+	bl := &countTestLogger{}
+	a := newAsyncLogger(bl, 10, DropPolicyBlock)
+
+	for i := 0; i < 100; i++ {
+		a.Log(context.TODO(), LevelInfo, "msg", nil)
+	}
+
+	assert.NoError(t, a.Flush(context.TODO()))
+	assert.Equal(t, 100, bl.count())
+	assert.NoError(t, a.Close())
+	assert.NoError(t, a.Close())
+	assert.NoError(t, a.Flush(context.TODO()))
+
+	a.Log(context.TODO(), LevelInfo, "msg after close", nil)
+	assert.Equal(t, 101, bl.count())
+	assert.Equal(t, uint64(0), a.Dropped())
+}
+
+func TestAsyncLogger_DropPolicy(t *testing.T) {
+	t.Run("Drop Newest", func(t *testing.T) {
+		bl := newBlockingTestLogger()
+		a := newAsyncLogger(bl, 2, DropPolicyDropNewest)
+		a.Log(context.TODO(), LevelInfo, "first", nil)
+		bl.waitStarted()
+
+		a.Log(context.TODO(), LevelInfo, "second", nil)
+		a.Log(context.TODO(), LevelInfo, "third", nil)
+		a.Log(context.TODO(), LevelInfo, "fourth", nil)
+		assert.Equal(t, uint64(1), a.Dropped())
+
+		close(bl.block)
+		assert.NoError(t, a.Close())
+		assert.Equal(t, []string{"first", "second", "third"}, bl.msgs)
+	})
+
+	t.Run("Drop Oldest", func(t *testing.T) {
+		bl := newBlockingTestLogger()
+		a := newAsyncLogger(bl, 2, DropPolicyDropOldest)
+		a.Log(context.TODO(), LevelInfo, "first", nil)
+		bl.waitStarted()
+
+		a.Log(context.TODO(), LevelInfo, "second", nil)
+		a.Log(context.TODO(), LevelInfo, "third", nil)
+		a.Log(context.TODO(), LevelInfo, "fourth", nil)
+		assert.Equal(t, uint64(1), a.Dropped())
+
+		close(bl.block)
+		assert.NoError(t, a.Close())
+		assert.Equal(t, []string{"first", "third", "fourth"}, bl.msgs)
+	})
+}
+
+func TestAsyncLogger_FlushContext(t *testing.T) {
+	bl := newBlockingTestLogger()
+	a := newAsyncLogger(bl, 1, DropPolicyBlock)
+	a.Log(context.TODO(), LevelInfo, "first", nil)
+	bl.waitStarted()
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, a.Flush(ctx))
+
+	close(bl.block)
+	assert.NoError(t, a.Flush(context.TODO()))
+	assert.NoError(t, a.Close())
+}
+
+// countTestLogger record log messages, optionally block first Log call until block channel closed.
+type countTestLogger struct {
+	mu      sync.Mutex
+	msgs    []string
+	block   chan struct{}
+	started sync.WaitGroup
+	once    sync.Once
+}
+
+func (cl *countTestLogger) Log(_ context.Context, _ Level, msg string, _ map[string]interface{}) {
+	if cl.block != nil {
+		cl.once.Do(func() { cl.started.Done() })
+		<-cl.block
+	}
+
+	cl.mu.Lock()
+	cl.msgs = append(cl.msgs, msg)
+	cl.mu.Unlock()
+}
+
+func (cl *countTestLogger) count() int {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+
+	return len(cl.msgs)
+}
+
+func newBlockingTestLogger() *countTestLogger {
+	cl := &countTestLogger{block: make(chan struct{})}
+	cl.started.Add(1)
+
+	return cl
+}
+
+// waitStarted wait until worker is blocked on first Log call, so queue is empty.
+func (cl *countTestLogger) waitStarted() {
+	cl.started.Wait()
+}
@@ -21,107 +21,127 @@ type connection struct {
 	driver.Conn
 	id     string
 	logger *logger
+	// tx is active transaction on this connection, sql.DB pin a transaction to a single connection
+	// so any call on this connection until Commit/Rollback is part of this transaction.
+	tx *transaction
 }
 
 // Begin implements driver.Conn
 func (c *connection) Begin() (driver.Tx, error) {
-	lvl, start, id := LevelDebug, time.Now(), c.logger.opt.uidGenerator.UniqueID()
-	logs := append(c.logData(), c.logger.withUID(c.logger.opt.txIDFieldname, id))
-	connTx, err := c.Conn.Begin() // nolint // disable static check on deprecated driver method
+	lvl, id := LevelDebug, c.logger.options().uidGenerator.UniqueID()
+	call := c.call(context.Background(), "Begin")
+	defer call.release()
 
+	call.TxID = id
+
+	err := c.logger.intercept(call)
 	if err != nil {
 		lvl = LevelError
 	}
 
-	c.logger.log(context.Background(), lvl, "Begin", start, err, logs...)
+	c.logger.logCall(call, lvl, nil)
 
-	return c.transaction(connTx, err, id)
+	return c.transaction(context.Background(), call.tx, err, id)
 }
 
 // Prepare implements driver.Conn
 func (c *connection) Prepare(query string) (driver.Stmt, error) {
-	lvl, start, id := c.logger.opt.preparerLevel, time.Now(), c.logger.opt.uidGenerator.UniqueID()
-	logs := append(c.logData(), c.logger.withQuery(query), c.logger.withUID(c.logger.opt.stmtIDFieldname, id))
-	driverStmt, err := c.Conn.Prepare(query)
+	opt := c.logger.options()
+	lvl, id := opt.preparerLevel, opt.uidGenerator.UniqueID()
+	call := c.call(context.Background(), "Prepare")
+	defer call.release()
+
+	call.Query, call.StmtID = query, id
 
+	err := c.logger.intercept(call)
 	if err != nil {
 		lvl = LevelError
 	}
 
-	c.logger.log(context.Background(), lvl, "Prepare", start, err, logs...)
+	c.logger.logCall(call, lvl, nil)
 
-	return c.statement(driverStmt, err, id, query)
+	return c.statement(context.Background(), call.stmt, err, id, call.Query)
 }
 
 // Prepare implements driver.Conn
 func (c *connection) Close() error {
-	lvl, start := LevelDebug, time.Now()
-	err := c.Conn.Close()
+	// connection is closed by pool long after the call which opened it (which may be any request),
+	// so Close log has no originating context, and Connect context is not retained by the connection.
+	lvl := LevelDebug
+	call := c.call(context.Background(), "Close")
+	defer call.release()
 
+	err := c.logger.intercept(call)
 	if err != nil {
 		lvl = LevelError
 	}
 
-	c.logger.log(context.Background(), lvl, "Close", start, err, c.logData()...)
+	c.logger.logCall(call, lvl, nil)
 
 	return err
 }
 
 // BeginTx implements driver.ConnBeginTx
 func (c *connection) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
-	drvTx, ok := c.Conn.(driver.ConnBeginTx)
-	if !ok {
+	if _, ok := c.Conn.(driver.ConnBeginTx); !ok {
 		return nil, driver.ErrSkip
 	}
 
-	lvl, start, id := LevelDebug, time.Now(), c.logger.opt.uidGenerator.UniqueID()
-	logs := append(c.logData(), c.logger.withUID(c.logger.opt.txIDFieldname, id))
-	connTx, err := drvTx.BeginTx(ctx, opts)
+	lvl, id := LevelDebug, c.logger.options().uidGenerator.UniqueID()
+	call := c.call(ctx, "BeginTx")
+	defer call.release()
+
+	call.TxID, call.txOpts = id, opts
 
+	err := c.logger.intercept(call)
 	if err != nil {
 		lvl = LevelError
 	}
 
-	c.logger.log(ctx, lvl, "BeginTx", start, err, logs...)
+	c.logger.logCall(call, lvl, nil)
 
-	return c.transaction(connTx, err, id)
+	return c.transaction(ctx, call.tx, err, id)
 }
 
 // PrepareContext implements driver.ConnPrepareContext
 func (c *connection) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
-	driverPrep, ok := c.Conn.(driver.ConnPrepareContext)
-	if !ok {
+	if _, ok := c.Conn.(driver.ConnPrepareContext); !ok {
 		return nil, driver.ErrSkip
 	}
 
-	lvl, start, id := c.logger.opt.preparerLevel, time.Now(), c.logger.opt.uidGenerator.UniqueID()
-	logs := append(c.logData(), c.logger.withQuery(query), c.logger.withUID(c.logger.opt.stmtIDFieldname, id))
-	driverStmt, err := driverPrep.PrepareContext(ctx, query)
+	opt := c.logger.options()
+	lvl, id := opt.preparerLevel, opt.uidGenerator.UniqueID()
+	call := c.call(ctx, "PrepareContext")
+	defer call.release()
+
+	call.Query, call.StmtID = c.logger.withSQLComment(ctx, query), id
 
+	err := c.logger.intercept(call)
 	if err != nil {
 		lvl = LevelError
 	}
 
-	c.logger.log(ctx, lvl, "PrepareContext", start, err, logs...)
+	c.logger.logCall(call, lvl, nil)
 
-	return c.statement(driverStmt, err, id, query)
+	return c.statement(ctx, call.stmt, err, id, call.Query)
 }
 
 // Ping implements driver.Pinger
 func (c *connection) Ping(ctx context.Context) error {
-	driverPinger, ok := c.Conn.(driver.Pinger)
-	if !ok {
+	if _, ok := c.Conn.(driver.Pinger); !ok {
 		return driver.ErrSkip
 	}
 
-	lvl, start := LevelDebug, time.Now()
-	err := driverPinger.Ping(ctx)
+	lvl := LevelDebug
+	call := c.call(ctx, "Ping")
+	defer call.release()
 
+	err := c.logger.intercept(call)
 	if err != nil {
 		lvl = LevelError
 	}
 
-	c.logger.log(ctx, lvl, "Ping", start, err, c.logData()...)
+	c.logger.logCall(call, lvl, nil)
 
 	return err
 }
@@ -129,102 +149,113 @@ func (c *connection) Ping(ctx context.Context) error {
 // Exec implements driver.Execer
 // Deprecated: use ExecContext() instead
 func (c *connection) Exec(query string, args []driver.Value) (driver.Result, error) {
-	driverExecer, ok := c.Conn.(driver.Execer) // nolint // disable static check on deprecated driver method
-	if !ok {
+	if _, ok := c.Conn.(driver.Execer); !ok { // nolint // disable static check on deprecated driver method
 		return nil, driver.ErrSkip
 	}
 
-	logs := append(c.logData(), c.logger.withQuery(query), c.logger.withArgs(args))
-	lvl, start := c.logger.opt.execerLevel, time.Now()
-	res, err := driverExecer.Exec(query, args)
+	lvl := c.logger.options().execerLevel
+	call := c.call(context.Background(), "Exec")
+	defer call.release()
 
+	call.Query, call.Args = query, valuesToNamedValues(args)
+
+	err := c.logger.intercept(call)
 	if err != nil {
 		lvl = LevelError
 	}
 
-	c.logger.log(context.Background(), lvl, "Exec", start, err, logs...)
+	c.tx.record(call.Result, err)
+	c.logger.logCall(call, lvl, nil)
 
-	return c.result(res, err, query, args)
+	return c.result(context.Background(), call.Result, err, call.Query, call.Args)
 }
 
 // ExecContext implements driver.ExecerContext
 func (c *connection) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
-	driverExecerContext, ok := c.Conn.(driver.ExecerContext)
-	if !ok {
+	if _, ok := c.Conn.(driver.ExecerContext); !ok {
 		return nil, driver.ErrSkip
 	}
 
-	logArgs := namedValuesToValues(args)
-	logs := append(c.logData(), c.logger.withQuery(query), c.logger.withArgs(logArgs))
-	lvl, start := c.logger.opt.execerLevel, time.Now()
-	res, err := driverExecerContext.ExecContext(ctx, query, args)
+	lvl := c.logger.options().execerLevel
+	call := c.call(ctx, "ExecContext")
+	defer call.release()
 
+	call.Query, call.Args = c.logger.withSQLComment(ctx, query), args
+
+	err := c.logger.intercept(call)
 	if err != nil {
 		lvl = LevelError
 	}
 
-	c.logger.log(ctx, lvl, "ExecContext", start, err, logs...)
+	c.tx.record(call.Result, err)
+	c.logger.logCall(call, lvl, nil)
 
-	return c.result(res, err, query, logArgs)
+	return c.result(ctx, call.Result, err, call.Query, call.Args)
 }
 
 // Query implements driver.Queryer
 // Deprecated: use QueryContext() instead
 func (c *connection) Query(query string, args []driver.Value) (driver.Rows, error) {
-	driverQueryer, ok := c.Conn.(driver.Queryer) // nolint // disable static check on deprecated driver method
-	if !ok {
+	if _, ok := c.Conn.(driver.Queryer); !ok { // nolint // disable static check on deprecated driver method
 		return nil, driver.ErrSkip
 	}
 
-	logs := append(c.logData(), c.logger.withQuery(query), c.logger.withArgs(args))
-	lvl, start := c.logger.opt.queryerLevel, time.Now()
-	res, err := driverQueryer.Query(query, args)
+	lvl := c.logger.options().queryerLevel
+	call := c.call(context.Background(), "Query")
+	defer call.release()
 
+	call.Query, call.Args = query, valuesToNamedValues(args)
+
+	err := c.logger.intercept(call)
 	if err != nil {
 		lvl = LevelError
 	}
 
-	c.logger.log(context.Background(), lvl, "Query", start, err, logs...)
+	c.tx.record(nil, err)
+	c.logger.logCall(call, lvl, nil)
 
-	return c.rows(res, err, query, args)
+	return c.rows(context.Background(), call.Rows, err, call.Query, call.Args)
 }
 
 // QueryContext implements driver.QueryerContext
 func (c *connection) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
-	driverQueryerContext, ok := c.Conn.(driver.QueryerContext)
-	if !ok {
+	if _, ok := c.Conn.(driver.QueryerContext); !ok {
 		return nil, driver.ErrSkip
 	}
 
-	logArgs := namedValuesToValues(args)
-	logs := append(c.logData(), c.logger.withQuery(query), c.logger.withArgs(logArgs))
-	lvl, start := c.logger.opt.queryerLevel, time.Now()
-	res, err := driverQueryerContext.QueryContext(ctx, query, args)
+	lvl := c.logger.options().queryerLevel
+	call := c.call(ctx, "QueryContext")
+	defer call.release()
+
+	call.Query, call.Args = c.logger.withSQLComment(ctx, query), args
 
+	err := c.logger.intercept(call)
 	if err != nil {
 		lvl = LevelError
 	}
 
-	c.logger.log(ctx, lvl, "QueryContext", start, err, logs...)
+	c.tx.record(nil, err)
+	c.logger.logCall(call, lvl, nil)
 
-	return c.rows(res, err, query, logArgs)
+	return c.rows(ctx, call.Rows, err, call.Query, call.Args)
 }
 
 // ResetSession implements driver.SessionResetter
 func (c *connection) ResetSession(ctx context.Context) error {
-	resetter, ok := c.Conn.(driver.SessionResetter)
-	if !ok {
+	if _, ok := c.Conn.(driver.SessionResetter); !ok {
 		return driver.ErrSkip
 	}
 
-	lvl, start := LevelTrace, time.Now()
-	err := resetter.ResetSession(ctx)
+	lvl := LevelTrace
+	call := c.call(ctx, "ResetSession")
+	defer call.release()
 
+	err := c.logger.intercept(call)
 	if err != nil {
 		lvl = LevelError
 	}
 
-	c.logger.log(context.Background(), lvl, "ResetSession", start, err, c.logData()...)
+	c.logger.logCall(call, lvl, nil)
 
 	return err
 }
@@ -236,53 +267,105 @@ func (c *connection) CheckNamedValue(nm *driver.NamedValue) error {
 		return driver.ErrSkip
 	}
 
-	lvl, start := LevelTrace, time.Now()
-	err := checker.CheckNamedValue(nm)
+	// argument check is not intercepted, the call only carry log data.
+	lvl := LevelTrace
+	call := c.call(context.Background(), "CheckNamedValue")
+	defer call.release()
 
-	if err != nil {
+	call.Start = time.Now()
+	call.Err = checker.CheckNamedValue(nm)
+
+	if call.Err != nil {
 		lvl = LevelError
 	}
 
-	c.logger.log(context.Background(), lvl, "CheckNamedValue", start, err, c.logData()...)
+	c.logger.logCall(call, lvl, nil)
 
-	return err
+	return call.Err
 }
 
-func (c *connection) transaction(tx driver.Tx, err error, id string) (driver.Tx, error) {
+func (c *connection) transaction(ctx context.Context, tx driver.Tx, err error, id string) (driver.Tx, error) {
 	if err != nil {
 		return tx, err
 	}
 
-	return &transaction{Tx: tx, logger: c.logger, connID: c.id, id: id}, nil
+	c.tx = &transaction{Tx: tx, ctx: ctx, logger: c.logger, connID: c.id, id: id, conn: c, begin: time.Now()}
+	c.tx.unwatch = c.logger.watch("Transaction", c.id, id, "", "")
+	c.tx.leak = c.logger.trackLeak(c.tx, "TxLeak", false)
+
+	return c.tx, nil
 }
 
-func (c *connection) statement(stmt driver.Stmt, err error, id, query string) (driver.Stmt, error) {
+func (c *connection) statement(ctx context.Context, stmt driver.Stmt, err error, id, query string) (driver.Stmt, error) {
 	if err != nil {
 		return stmt, err
 	}
 
-	return &statement{Stmt: stmt, query: query, logger: c.logger, connID: c.id, id: id}, nil
+	s := &statement{Stmt: stmt, ctx: ctx, query: query, logger: c.logger, connID: c.id, conn: c, id: id}
+	s.leak = c.logger.trackLeak(s, "StmtLeak", false)
+
+	return s, nil
 }
 
-func (c *connection) rows(res driver.Rows, err error, query string, args []driver.Value) (driver.Rows, error) {
-	if !c.logger.opt.wrapResult || err != nil {
+func (c *connection) rows(ctx context.Context, res driver.Rows, err error, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if !c.logger.options().wrapResult || err != nil {
 		return res, err
 	}
 
-	return &rows{Rows: res, logger: c.logger, connID: c.id, query: query, args: args}, nil
+	r := &rows{Rows: res, ctx: ctx, logger: c.logger, connID: c.id, txID: c.txID(), query: query, args: args, openedAt: time.Now()}
+	r.leak = c.logger.trackLeak(r, "RowsLeak", true)
+
+	return r, nil
 }
 
-func (c *connection) result(res driver.Result, err error, query string, args []driver.Value) (driver.Result, error) {
-	if !c.logger.opt.wrapResult || err != nil {
+func (c *connection) result(ctx context.Context, res driver.Result, err error, query string, args []driver.NamedValue) (driver.Result, error) {
+	if !c.logger.options().wrapResult || err != nil {
 		return res, err
 	}
 
-	return &result{Result: res, logger: c.logger, connID: c.id, query: query, args: args}, nil
+	return &result{Result: res, ctx: ctx, logger: c.logger, connID: c.id, txID: c.txID(), query: query, args: args}, nil
 }
 
-// logData default log data for connection.
-func (c *connection) logData() []dataFunc {
-	return []dataFunc{
-		c.logger.withUID(c.logger.opt.connIDFieldname, c.id),
+// txID return active transaction id, empty if no active transaction.
+func (c *connection) txID() string {
+	return c.tx.uid()
+}
+
+// call get interceptor call descriptor for connection call.
+func (c *connection) call(ctx context.Context, op string) *Call {
+	call := c.logger.newCall(ctx, op, c)
+	call.ConnID, call.TxID = c.id, c.txID()
+
+	return call
+}
+
+// invoke implements invoker, it do the actual driver call.
+// nolint // disable static check on deprecated driver method
+func (c *connection) invoke(call *Call) (err error) {
+	switch call.Op {
+	case "Begin":
+		call.tx, err = c.Conn.Begin()
+	case "Prepare":
+		call.stmt, err = c.Conn.Prepare(call.Query)
+	case "Close":
+		err = c.Conn.Close()
+	case "BeginTx":
+		call.tx, err = c.Conn.(driver.ConnBeginTx).BeginTx(call.Ctx, call.txOpts)
+	case "PrepareContext":
+		call.stmt, err = c.Conn.(driver.ConnPrepareContext).PrepareContext(call.Ctx, call.Query)
+	case "Ping":
+		err = c.Conn.(driver.Pinger).Ping(call.Ctx)
+	case "Exec":
+		call.Result, err = c.Conn.(driver.Execer).Exec(call.Query, namedValuesToValues(call.Args))
+	case "ExecContext":
+		call.Result, err = c.Conn.(driver.ExecerContext).ExecContext(call.Ctx, call.Query, call.Args)
+	case "Query":
+		call.Rows, err = c.Conn.(driver.Queryer).Query(call.Query, namedValuesToValues(call.Args))
+	case "QueryContext":
+		call.Rows, err = c.Conn.(driver.QueryerContext).QueryContext(call.Ctx, call.Query, call.Args)
+	case "ResetSession":
+		err = c.Conn.(driver.SessionResetter).ResetSession(call.Ctx)
 	}
+
+	return err
 }
@@ -716,6 +716,129 @@ func TestConnection_CheckNamedValue(t *testing.T) {
 	})
 }
 
+func TestConnection_TransactionID(t *testing.T) {
+	driverConnMock := &driverConnTxMock{}
+	txMock := &transactionMock{}
+	stmtMock := &statementExecerContextMock{}
+	driverConnMock.On("BeginTx", mock.Anything, mock.Anything).Return(txMock, nil)
+	driverConnMock.On("ExecContext", mock.Anything, mock.Anything, mock.Anything).Return(driver.ResultNoRows, nil)
+	driverConnMock.On("PrepareContext", mock.Anything).Return(stmtMock, nil)
+	stmtMock.On("ExecContext", mock.Anything, mock.Anything).Return(driver.ResultNoRows, nil)
+	txMock.On("Commit").Return(nil)
+
+	q := "UPDATE tt SET a = 1"
+	conn := &connection{Conn: driverConnMock, logger: testLogger, id: testLogger.opt.uidGenerator.UniqueID()}
+	tx, err := conn.BeginTx(context.TODO(), driver.TxOptions{})
+	assert.NoError(t, err)
+
+	var output bufLog
+	err = json.Unmarshal(bufLogger.Bytes(), &output)
+	assert.NoError(t, err)
+	txID := output.Data[testOpts.txIDFieldname]
+	assert.NotEmpty(t, txID)
+
+	res, err := conn.ExecContext(context.TODO(), q, nil)
+	assert.NoError(t, err)
+
+	err = json.Unmarshal(bufLogger.Bytes(), &output)
+	assert.NoError(t, err)
+	assert.Equal(t, "ExecContext", output.Message)
+	assert.Equal(t, txID, output.Data[testOpts.txIDFieldname])
+
+	_, _ = res.RowsAffected()
+	output = bufLog{}
+	err = json.Unmarshal(bufLogger.Bytes(), &output)
+	assert.NoError(t, err)
+	assert.Equal(t, "ResultRowsAffected", output.Message)
+	assert.Equal(t, txID, output.Data[testOpts.txIDFieldname])
+
+	stmt, err := conn.PrepareContext(context.TODO(), q)
+	assert.NoError(t, err)
+
+	output = bufLog{}
+	err = json.Unmarshal(bufLogger.Bytes(), &output)
+	assert.NoError(t, err)
+	assert.Equal(t, "PrepareContext", output.Message)
+	assert.Equal(t, txID, output.Data[testOpts.txIDFieldname])
+
+	_, err = stmt.(driver.StmtExecContext).ExecContext(context.TODO(), nil)
+	assert.NoError(t, err)
+
+	output = bufLog{}
+	err = json.Unmarshal(bufLogger.Bytes(), &output)
+	assert.NoError(t, err)
+	assert.Equal(t, "StmtExecContext", output.Message)
+	assert.Equal(t, txID, output.Data[testOpts.txIDFieldname])
+
+	err = tx.Commit()
+	assert.NoError(t, err)
+
+	output = bufLog{}
+	err = json.Unmarshal(bufLogger.Bytes(), &output)
+	assert.NoError(t, err)
+	assert.Equal(t, "Commit", output.Message)
+	assert.Equal(t, txID, output.Data[testOpts.txIDFieldname])
+	assert.Equal(t, float64(2), output.Data["tx_statements"])
+	assert.Equal(t, false, output.Data["tx_had_error"])
+
+	_, err = conn.ExecContext(context.TODO(), q, nil)
+	assert.NoError(t, err)
+
+	output = bufLog{}
+	err = json.Unmarshal(bufLogger.Bytes(), &output)
+	assert.NoError(t, err)
+	assert.Equal(t, "ExecContext", output.Message)
+	assert.NotContains(t, output.Data, testOpts.txIDFieldname)
+}
+
+func TestConnection_OriginatingContext(t *testing.T) {
+	cfg := *testOpts
+	WithMinimumLevel(LevelTrace)(&cfg)
+	WithContextFields(ctxFieldsTest)(&cfg)
+	l := &logger{logger: bufLogger, opt: &cfg}
+
+	driverConnMock := &driverConnCommentMock{}
+	txMock := &transactionMock{}
+	rowsMock := &rowsMock{}
+	driverConnMock.On("BeginTx", mock.Anything, mock.Anything).Return(txMock, nil)
+	driverConnMock.On("QueryContext", mock.Anything, mock.Anything, mock.Anything).Return(rowsMock, nil)
+	rowsMock.On("Next", mock.Anything).Return(nil)
+	rowsMock.On("Close").Return(nil)
+	txMock.On("Commit").Return(nil)
+
+	conn := &connection{Conn: driverConnMock, logger: l, id: "conn"}
+	assertRequestID := func(msg string, id interface{}) {
+		var output bufLog
+		assert.NoError(t, json.Unmarshal(bufLogger.Bytes(), &output))
+		assert.Equal(t, msg, output.Message)
+		assert.Equal(t, id, output.Data["request_id"])
+	}
+
+	tx, err := conn.BeginTx(context.WithValue(context.TODO(), ctxFieldKey{}, "req-tx"), driver.TxOptions{})
+	assert.NoError(t, err)
+
+	rows, err := conn.QueryContext(context.WithValue(context.TODO(), ctxFieldKey{}, "req-rows"), "SELECT 1", nil)
+	assert.NoError(t, err)
+	assert.NoError(t, rows.Next(make([]driver.Value, 1)))
+	assertRequestID("RowsNext", "req-rows")
+	assert.NoError(t, rows.Close())
+	assertRequestID("RowsClose", "req-rows")
+
+	assert.NoError(t, tx.Commit())
+	assertRequestID("Commit", "req-tx")
+
+	closeConnMock := &driverConnCommentMock{}
+	closeConnMock.On("Close").Return(nil)
+	mockDriver := &driverMock{}
+	mockDriver.On("Open", mock.Anything).Return(closeConnMock, nil)
+
+	dc, err := (&connector{dsn: "test", driver: mockDriver, logger: l}).Connect(context.WithValue(context.TODO(), ctxFieldKey{}, "req-conn"))
+	assert.NoError(t, err)
+	assert.NoError(t, dc.Close())
+	// connection outlives its Connect request, so Close is not logged with its request id.
+	assertRequestID("Close", nil)
+}
+
 type driverConnMock struct {
 	mock.Mock
 }
@@ -786,6 +909,22 @@ func (m *driverConnWithContextMock) PrepareContext(ctx context.Context, query st
 	return args.Get(0).(driver.Stmt), args.Error(1)
 }
 
+type driverConnTxMock struct {
+	driverConnExecerContextMock
+}
+
+func (m *driverConnTxMock) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	args := m.Called(ctx, opts)
+
+	return args.Get(0).(driver.Tx), args.Error(1)
+}
+
+func (m *driverConnTxMock) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	args := m.Called(query)
+
+	return args.Get(0).(driver.Stmt), args.Error(1)
+}
+
 type driverConnPingerMock struct {
 	driverConnMock
 }
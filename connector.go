@@ -3,32 +3,104 @@ package sqldblogger
 import (
 	"context"
 	"database/sql/driver"
-	"time"
+	"io"
+	"sync"
 )
 
-// connector is a wrapped connector to a given driver and should implements:
+// connector is a wrapped connector to a given driver or driver.Connector and should implements:
 // - driver.Connector
+// - io.Closer
 type connector struct {
-	dsn    string
-	driver driver.Driver
-	logger *logger
+	dsn       string
+	driver    driver.Driver
+	connector driver.Connector
+	logger    *logger
+	// driverConnector is driver.Connector opened once from driver.DriverContext (if implemented by driver).
+	driverConnectorOnce sync.Once
+	driverConnector     driver.Connector
+	driverConnectorErr  error
+	// owner is controller of logger created for this connector only (see: OpenDriver), nil if logger is shared.
+	owner *Controller
 }
 
 // Connect implement driver.Connector which will open new db connection if none exist
 func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
-	start, id := time.Now(), c.logger.opt.uidGenerator.UniqueID()
-	logID := c.logger.withUID(c.logger.opt.connIDFieldname, id)
-	conn, err := c.driver.Open(c.dsn)
+	lvl, id := LevelDebug, c.logger.options().uidGenerator.UniqueID()
+	call := c.logger.newCall(ctx, "Connect", c)
+	defer call.release()
+
+	call.ConnID = id
+
+	err := c.logger.intercept(call)
+	if err != nil {
+		lvl = LevelError
+	}
+
+	c.logger.logCall(call, lvl, nil)
 
 	if err != nil {
-		c.logger.log(ctx, LevelError, "Connect", start, err, logID)
 		return nil, err
 	}
 
-	c.logger.log(ctx, LevelDebug, "Connect", start, err, logID)
+	return &connection{Conn: call.conn, logger: c.logger, id: id}, nil
+}
+
+// invoke implements invoker, it do the actual driver call.
+func (c *connector) invoke(call *Call) (err error) {
+	call.conn, err = c.connect(call.Ctx)
 
-	return &connection{Conn: conn, logger: c.logger, id: id}, nil
+	return err
 }
 
 // Driver implement driver.Connector
-func (c *connector) Driver() driver.Driver { return c.driver }
+func (c *connector) Driver() driver.Driver {
+	if c.connector != nil {
+		return c.connector.Driver()
+	}
+
+	return c.driver
+}
+
+// Close implement io.Closer, it will close wrapped driver.Connector if it implements io.Closer,
+// then stop the logger if it is owned by this connector. sql.DB.Close() will call this method.
+func (c *connector) Close() error {
+	var err error
+
+	if closer, ok := c.connector.(io.Closer); ok {
+		err = closer.Close()
+	} else if closer, ok := c.driverConnector.(io.Closer); ok {
+		err = closer.Close()
+	}
+
+	if c.owner != nil {
+		if cerr := c.owner.Close(); err == nil {
+			err = cerr
+		}
+	}
+
+	return err
+}
+
+// connect open new driver.Conn from wrapped driver.Connector if any.
+// If driver implements driver.DriverContext, it will use driver.Connector from OpenConnector(dsn)
+// so the given context reach the driver, otherwise fallback to legacy driver.Open().
+func (c *connector) connect(ctx context.Context) (driver.Conn, error) {
+	if c.connector != nil {
+		return c.connector.Connect(ctx)
+	}
+
+	drvCtx, ok := c.driver.(driver.DriverContext)
+	if !ok {
+		return c.driver.Open(c.dsn)
+	}
+
+	c.driverConnectorOnce.Do(func() {
+		c.driverConnector, c.driverConnectorErr = drvCtx.OpenConnector(c.dsn)
+	})
+
+	if c.driverConnectorErr != nil {
+		return nil, c.driverConnectorErr
+	}
+
+	return c.driverConnector.Connect(ctx)
+}
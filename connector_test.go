@@ -5,6 +5,7 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -50,4 +51,194 @@ func TestConnector_Driver(t *testing.T) {
 	drv := con.Driver()
 
 	assert.Equal(t, mockDriver, drv)
+
+	t.Run("Wrapped Connector", func(t *testing.T) {
+		mockConnector := &connectorMock{}
+		mockConnector.On("Driver").Return(mockDriver)
+		con := &connector{connector: mockConnector, logger: testLogger}
+
+		assert.Equal(t, mockDriver, con.Driver())
+	})
+}
+
+func TestConnector_ConnectWrapped(t *testing.T) {
+	t.Run("Connect Error", func(t *testing.T) {
+		mockConnector := &connectorMock{}
+		mockConnector.On("Connect", mock.Anything).Return(&driverConnMock{}, driver.ErrBadConn)
+
+		con := &connector{connector: mockConnector, logger: testLogger}
+		_, err := con.Connect(context.TODO())
+		assert.Error(t, err)
+
+		var output bufLog
+		err = json.Unmarshal(bufLogger.Bytes(), &output)
+		assert.NoError(t, err)
+		assert.Equal(t, "Connect", output.Message)
+		assert.Equal(t, LevelError.String(), output.Level)
+		assert.NotEmpty(t, output.Data[testOpts.connIDFieldname])
+	})
+
+	t.Run("Connect Success", func(t *testing.T) {
+		mockConnector := &connectorMock{}
+		mockConnector.On("Connect", mock.Anything).Return(&driverConnMock{}, nil)
+
+		con := &connector{connector: mockConnector, logger: testLogger}
+		conn, err := con.Connect(context.TODO())
+		assert.NoError(t, err)
+		assert.IsType(t, &connection{}, conn)
+
+		var output bufLog
+		err = json.Unmarshal(bufLogger.Bytes(), &output)
+		assert.NoError(t, err)
+		assert.Equal(t, "Connect", output.Message)
+		assert.Equal(t, LevelDebug.String(), output.Level)
+		assert.NotEmpty(t, output.Data[testOpts.connIDFieldname])
+	})
+}
+
+func TestConnector_ConnectDriverContext(t *testing.T) {
+	t.Run("OpenConnector Error", func(t *testing.T) {
+		mockDriver := &driverContextMock{}
+		mockDriver.On("OpenConnector", "test").Return(&connectorMock{}, driver.ErrBadConn).Once()
+
+		con := &connector{dsn: "test", driver: mockDriver, logger: testLogger}
+		_, err := con.Connect(context.TODO())
+		assert.Equal(t, driver.ErrBadConn, err)
+		_, err = con.Connect(context.TODO())
+		assert.Equal(t, driver.ErrBadConn, err)
+		mockDriver.AssertExpectations(t)
+		mockDriver.AssertNotCalled(t, "Open", mock.Anything)
+
+		var output bufLog
+		err = json.Unmarshal(bufLogger.Bytes(), &output)
+		assert.NoError(t, err)
+		assert.Equal(t, "Connect", output.Message)
+		assert.Equal(t, LevelError.String(), output.Level)
+	})
+
+	t.Run("Connect With Context", func(t *testing.T) {
+		type ctxKey struct{}
+		ctx := context.WithValue(context.TODO(), ctxKey{}, "value")
+		mockConnector := &connectorMock{}
+		mockConnector.On("Connect", ctx).Return(&driverConnMock{}, nil).Twice()
+		mockDriver := &driverContextMock{}
+		mockDriver.On("OpenConnector", "test").Return(mockConnector, nil).Once()
+
+		con := &connector{dsn: "test", driver: mockDriver, logger: testLogger}
+		_, err := con.Connect(ctx)
+		assert.NoError(t, err)
+		conn, err := con.Connect(ctx)
+		assert.NoError(t, err)
+		assert.IsType(t, &connection{}, conn)
+		mockDriver.AssertExpectations(t)
+		mockConnector.AssertExpectations(t)
+		mockDriver.AssertNotCalled(t, "Open", mock.Anything)
+
+		var output bufLog
+		err = json.Unmarshal(bufLogger.Bytes(), &output)
+		assert.NoError(t, err)
+		assert.Equal(t, "Connect", output.Message)
+		assert.Equal(t, LevelDebug.String(), output.Level)
+	})
+}
+
+func TestConnector_Close(t *testing.T) {
+	t.Run("Non Closer", func(t *testing.T) {
+		con := &connector{dsn: "test", driver: &driverMock{}, logger: testLogger}
+		assert.NoError(t, con.Close())
+	})
+
+	t.Run("Closer", func(t *testing.T) {
+		mockConnector := &connectorCloserMock{}
+		mockConnector.On("Close").Return(driver.ErrBadConn)
+
+		con := &connector{connector: mockConnector, logger: testLogger}
+		assert.Equal(t, driver.ErrBadConn, con.Close())
+	})
+
+	t.Run("Driver Context Closer", func(t *testing.T) {
+		mockConnector := &connectorCloserMock{}
+		mockConnector.On("Connect", mock.Anything).Return(&driverConnMock{}, nil)
+		mockConnector.On("Close").Return(nil).Once()
+		mockDriver := &driverContextMock{}
+		mockDriver.On("OpenConnector", "test").Return(mockConnector, nil)
+
+		con := &connector{dsn: "test", driver: mockDriver, logger: testLogger}
+		_, err := con.Connect(context.TODO())
+		assert.NoError(t, err)
+		assert.NoError(t, con.Close())
+		mockConnector.AssertExpectations(t)
+	})
+}
+
+type connectorMock struct {
+	mock.Mock
+}
+
+func (m *connectorMock) Connect(ctx context.Context) (driver.Conn, error) {
+	arg := m.Called(ctx)
+
+	return arg.Get(0).(driver.Conn), arg.Error(1)
+}
+
+func (m *connectorMock) Driver() driver.Driver {
+	return m.Called().Get(0).(driver.Driver)
+}
+
+type driverContextMock struct {
+	driverMock
+}
+
+func (m *driverContextMock) OpenConnector(name string) (driver.Connector, error) {
+	arg := m.Called(name)
+
+	return arg.Get(0).(driver.Connector), arg.Error(1)
+}
+
+type connectorCloserMock struct {
+	connectorMock
+}
+
+func (m *connectorCloserMock) Close() error {
+	return m.Called().Error(0)
+}
+
+func TestConnector_CloseOwnedLogger(t *testing.T) {
+	t.Run("Owned", func(t *testing.T) {
+		sl := &syncTestLogger{}
+		driverConn := &driverConnPingerMock{}
+		driverConn.On("Ping").Return(nil)
+		driverConn.On("Close").Return(nil)
+		mockConnector := &connectorMock{}
+		mockConnector.On("Connect", mock.Anything).Return(driverConn, nil)
+
+		db := OpenConnector(mockConnector, sl, WithAsyncLogger(16, DropPolicyBlock), WithWatchdog(time.Minute, 0))
+		assert.NoError(t, db.Ping())
+		assert.NoError(t, db.Close())
+
+		// queued logs are delivered on close
+		logs := sl.get()
+		assert.Len(t, logs, 3)
+		assert.Equal(t, "Connect", logs[0].msg)
+		assert.Equal(t, "Close", logs[2].msg)
+
+		con := NewConnector(mockConnector, sl, WithAsyncLogger(16, DropPolicyBlock), WithWatchdog(time.Minute, 0)).(*connector)
+		assert.NoError(t, con.Close())
+
+		select {
+		case <-con.owner.logger.watchdog.stop:
+		default:
+			t.Error("watchdog is not stopped")
+		}
+	})
+
+	t.Run("Shared", func(t *testing.T) {
+		ctl := NewController(&syncTestLogger{}, WithAsyncLogger(16, DropPolicyBlock))
+		defer ctl.Close()
+
+		con := ctl.NewConnector(&connectorMock{}).(*connector)
+		assert.Nil(t, con.owner)
+		assert.NoError(t, con.Close())
+		assert.False(t, ctl.logger.logger.(*asyncLogger).closed)
+	})
 }
@@ -0,0 +1,96 @@
+package sqldblogger
+
+import "context"
+
+// overrideCtxKey is context key of per-call logging override.
+type overrideCtxKey struct{}
+
+// callOverride is per-call logging override set by WithLevel(), Silence() and WithTag().
+type callOverride struct {
+	hasLevel bool
+	level    Level
+	silent   bool
+	tags     map[string]interface{}
+}
+
+// overrideFromContext return per-call logging override from given context, nil if none.
+func overrideFromContext(ctx context.Context) *callOverride {
+	if ctx == nil {
+		return nil
+	}
+
+	o, _ := ctx.Value(overrideCtxKey{}).(*callOverride)
+
+	return o
+}
+
+// withOverride return context with a copy of existing override modified by fn.
+func withOverride(ctx context.Context, fn func(o *callOverride)) context.Context {
+	o := &callOverride{}
+	if parent := overrideFromContext(ctx); parent != nil {
+		*o = *parent
+	}
+
+	fn(o)
+
+	return context.WithValue(ctx, overrideCtxKey{}, o)
+}
+
+// WithLevel return context which override minimum log level for any call using it (or derived from it),
+// e.g: enable LevelTrace for a single request.
+func WithLevel(ctx context.Context, lvl Level) context.Context {
+	return withOverride(ctx, func(o *callOverride) {
+		o.hasLevel = true
+		o.level = lvl
+	})
+}
+
+// Silence return context which disable log for any call using it (or derived from it),
+// e.g: health check ping.
+func Silence(ctx context.Context) context.Context {
+	return withOverride(ctx, func(o *callOverride) {
+		o.silent = true
+	})
+}
+
+// WithTag return context which add given field to log data of any call using it (or derived from it).
+// Tag will not override sqldblogger fields.
+func WithTag(ctx context.Context, name string, value interface{}) context.Context {
+	return withOverride(ctx, func(o *callOverride) {
+		tags := make(map[string]interface{}, len(o.tags)+1)
+		for k, v := range o.tags {
+			tags[k] = v
+		}
+
+		tags[name] = value
+		o.tags = tags
+	})
+}
+
+// silenced return true if log is disabled, it is safe to call on nil override.
+func (o *callOverride) silenced() bool {
+	return o != nil && o.silent
+}
+
+// minimumLevel return overridden minimum level if any, otherwise given default level.
+// It is safe to call on nil override.
+func (o *callOverride) minimumLevel(def Level) Level {
+	if o == nil || !o.hasLevel {
+		return def
+	}
+
+	return o.level
+}
+
+// withTags add tags to data, existing field is not overridden. It is safe to call on nil override.
+func (o *callOverride) withTags(data map[string]interface{}) {
+	if o == nil {
+		return
+	}
+
+	for k, v := range o.tags {
+		if _, ok := data[k]; !ok {
+			data[k] = v
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package sqldblogger
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithLevel(t *testing.T) {
+	cfg := &options{}
+	setDefaultOptions(cfg)
+	WithMinimumLevel(LevelInfo)(cfg)
+	bl := &bufferTestLogger{}
+	l := &logger{opt: cfg, logger: bl}
+
+	l.logCall(&Call{Ctx: context.TODO(), Op: "RowsNext", Start: time.Now()}, LevelTrace, nil)
+	assert.Empty(t, bl.Bytes())
+
+	l.logCall(&Call{Ctx: WithLevel(context.TODO(), LevelTrace), Op: "RowsNext", Start: time.Now()}, LevelTrace, nil)
+
+	var content bufLog
+	assert.NoError(t, json.Unmarshal(bl.Bytes(), &content))
+	assert.Equal(t, "RowsNext", content.Message)
+	bl.Reset()
+
+	l.logCall(&Call{Ctx: WithLevel(context.TODO(), LevelError), Op: "QueryContext", Start: time.Now()}, LevelInfo, nil)
+	assert.Empty(t, bl.Bytes())
+}
+
+func TestSilence(t *testing.T) {
+	cfg := &options{}
+	setDefaultOptions(cfg)
+	bl := &bufferTestLogger{}
+	l := &logger{opt: cfg, logger: bl}
+
+	ctx := WithLevel(Silence(context.TODO()), LevelTrace)
+	l.logCall(&Call{Ctx: ctx, Op: "Ping", Start: time.Now()}, LevelError, nil)
+	assert.Empty(t, bl.Bytes())
+}
+
+func TestWithTag(t *testing.T) {
+	cfg := &options{}
+	setDefaultOptions(cfg)
+	bl := &bufferTestLogger{}
+	l := &logger{opt: cfg, logger: bl}
+
+	parent := WithTag(context.TODO(), "handler", "users")
+	ctx := WithTag(parent, "debug", true)
+	ctx = WithTag(ctx, cfg.sqlQueryFieldname, "not overridden")
+	l.logCall(&Call{Ctx: ctx, Op: "QueryContext", Start: time.Now(), Query: "SELECT 1"}, LevelInfo, nil)
+
+	var content bufLog
+	assert.NoError(t, json.Unmarshal(bl.Bytes(), &content))
+	assert.Equal(t, "users", content.Data["handler"])
+	assert.Equal(t, true, content.Data["debug"])
+	assert.Equal(t, "SELECT 1", content.Data[cfg.sqlQueryFieldname])
+
+	// parent context is not modified
+	content = bufLog{}
+	l.logCall(&Call{Ctx: parent, Op: "QueryContext", Start: time.Now()}, LevelInfo, nil)
+	assert.NoError(t, json.Unmarshal(bl.Bytes(), &content))
+	assert.Equal(t, "users", content.Data["handler"])
+	assert.NotContains(t, content.Data, "debug")
+}
+
+func TestCallOverride_Nil(t *testing.T) {
+	var o *callOverride
+	assert.Nil(t, overrideFromContext(nil)) // nolint // nil context is tested on purpose
+	assert.False(t, o.silenced())
+	assert.Equal(t, LevelInfo, o.minimumLevel(LevelInfo))
+	o.withTags(map[string]interface{}{})
+}
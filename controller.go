@@ -0,0 +1,114 @@
+package sqldblogger
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"sync"
+	"time"
+)
+
+// Controller is a handle to logger shared by every *sql.DB or driver.Connector opened from it.
+//
+// Use it to Flush() and Close() asynchronous logger (see: WithAsyncLogger) on shutdown,
+// or to change logging options at runtime (Set* methods) while the pool is in use.
+type Controller struct {
+	logger *logger
+	// mu serialize runtime options update.
+	mu sync.Mutex
+}
+
+// NewController create Controller with given logger and options.
+func NewController(lg Logger, opt ...Option) *Controller {
+	return &Controller{logger: newLogger(lg, opt...)}
+}
+
+// OpenDriver wrap given driver with controller logger and return *sql.DB.
+func (c *Controller) OpenDriver(dsn string, drv driver.Driver) *sql.DB {
+	return sql.OpenDB(&connector{dsn: dsn, driver: drv, logger: c.logger})
+}
+
+// OpenConnector wrap given driver.Connector with controller logger and return *sql.DB.
+func (c *Controller) OpenConnector(conn driver.Connector) *sql.DB {
+	return sql.OpenDB(c.NewConnector(conn))
+}
+
+// NewConnector wrap given driver.Connector with controller logger and return wrapped driver.Connector.
+func (c *Controller) NewConnector(conn driver.Connector) driver.Connector {
+	return &connector{connector: conn, logger: c.logger}
+}
+
+// Flush wait until every queued log delivered to Logger, or given context done.
+// It does nothing if asynchronous logger is not enabled.
+func (c *Controller) Flush(ctx context.Context) error {
+	if a, ok := c.logger.logger.(*asyncLogger); ok {
+		return a.Flush(ctx)
+	}
+
+	return nil
+}
+
+// Close stop watchdog (see: WithWatchdog) and asynchronous logger worker after every queued log delivered.
+// Log after Close() will be delivered synchronously.
+func (c *Controller) Close() error {
+	c.logger.watchdog.close()
+
+	if a, ok := c.logger.logger.(*asyncLogger); ok {
+		return a.Close()
+	}
+
+	return nil
+}
+
+// DroppedLogs return total logs dropped by asynchronous logger drop policy.
+func (c *Controller) DroppedLogs() uint64 {
+	if a, ok := c.logger.logger.(*asyncLogger); ok {
+		return a.Dropped()
+	}
+
+	return 0
+}
+
+// SetMinimumLevel change minimum log level at runtime (see: WithMinimumLevel).
+func (c *Controller) SetMinimumLevel(lvl Level) {
+	c.update(WithMinimumLevel(lvl))
+}
+
+// SetPreparerLevel change Prepare(Context) log level at runtime (see: WithPreparerLevel).
+func (c *Controller) SetPreparerLevel(lvl Level) {
+	c.update(WithPreparerLevel(lvl))
+}
+
+// SetQueryerLevel change Query(Context) log level at runtime (see: WithQueryerLevel).
+func (c *Controller) SetQueryerLevel(lvl Level) {
+	c.update(WithQueryerLevel(lvl))
+}
+
+// SetExecerLevel change Exec(Context) log level at runtime (see: WithExecerLevel).
+func (c *Controller) SetExecerLevel(lvl Level) {
+	c.update(WithExecerLevel(lvl))
+}
+
+// SetLogArguments change query arguments logging at runtime (see: WithLogArguments).
+func (c *Controller) SetLogArguments(flag bool) {
+	c.update(WithLogArguments(flag))
+}
+
+// SetSampler change log sampler at runtime, nil will disable sampling (see: WithSampler).
+// Sampled out events not yet summarized are discarded.
+func (c *Controller) SetSampler(sampler Sampler) {
+	c.update(WithSampler(sampler))
+}
+
+// SetSlowQueryThreshold change slow query threshold and level at runtime (see: WithSlowQueryThreshold).
+func (c *Controller) SetSlowQueryThreshold(threshold time.Duration, lvl Level) {
+	c.update(WithSlowQueryThreshold(threshold, lvl))
+}
+
+// update apply given options to every *sql.DB or driver.Connector opened from this controller.
+func (c *Controller) update(opts ...Option) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.logger.update(opts...)
+}
@@ -0,0 +1,120 @@
+package sqldblogger
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestController(t *testing.T) {
+	t.Run("Synchronous", func(t *testing.T) {
+		ctrl := NewController(bufLogger)
+		assert.NoError(t, ctrl.Flush(context.TODO()))
+		assert.NoError(t, ctrl.Close())
+		assert.Equal(t, uint64(0), ctrl.DroppedLogs())
+	})
+
+	t.Run("Asynchronous", func(t *testing.T) {
+		mockDriver := &driverMock{}
+		mockDriver.On("Open", mock.Anything).Return(&driverConnMock{}, driver.ErrBadConn)
+		bl := &countTestLogger{}
+
+		ctrl := NewController(bl, WithAsyncLogger(10, DropPolicyDropNewest))
+		db := ctrl.OpenDriver("test", mockDriver)
+		_, ok := interface{}(db).(*sql.DB)
+		assert.True(t, ok)
+		assert.Error(t, db.Ping())
+
+		assert.NoError(t, ctrl.Flush(context.TODO()))
+		assert.Contains(t, bl.msgs, "Connect")
+		assert.NoError(t, ctrl.Close())
+		assert.Equal(t, uint64(0), ctrl.DroppedLogs())
+	})
+
+	t.Run("Connector", func(t *testing.T) {
+		mockConnector := &connectorMock{}
+		mockConnector.On("Connect", mock.Anything).Return(&driverConnMock{}, driver.ErrBadConn)
+
+		ctrl := NewController(bufLogger)
+		db := ctrl.OpenConnector(mockConnector)
+		assert.Error(t, db.Ping())
+
+		con, ok := ctrl.NewConnector(mockConnector).(*connector)
+		assert.True(t, ok)
+		assert.Equal(t, ctrl.logger, con.logger)
+	})
+}
+
+func TestController_RuntimeOptions(t *testing.T) {
+	bl := &syncTestLogger{}
+	ctrl := NewController(bl, WithMinimumLevel(LevelInfo))
+	l := ctrl.logger
+	initial := l.options()
+
+	l.logCall(&Call{Ctx: context.TODO(), Op: "Ping", Start: time.Now()}, LevelDebug, nil)
+	assert.Empty(t, bl.get())
+
+	ctrl.SetMinimumLevel(LevelTrace)
+	ctrl.SetPreparerLevel(LevelDebug)
+	ctrl.SetQueryerLevel(LevelDebug)
+	ctrl.SetExecerLevel(LevelDebug)
+	ctrl.SetLogArguments(false)
+	ctrl.SetSampler(NewRatioSampler(1))
+	ctrl.SetSlowQueryThreshold(time.Second, LevelError)
+
+	opt := l.options()
+	assert.Equal(t, LevelTrace, opt.minimumLogLevel)
+	assert.Equal(t, LevelDebug, opt.preparerLevel)
+	assert.Equal(t, LevelDebug, opt.queryerLevel)
+	assert.Equal(t, LevelDebug, opt.execerLevel)
+	assert.False(t, opt.logArgs)
+	assert.NotNil(t, opt.sampler)
+	assert.Equal(t, time.Second, opt.slowQueryThreshold)
+	assert.Equal(t, LevelError, opt.slowQueryLevel)
+
+	// initial options is never mutated
+	assert.Equal(t, LevelInfo, initial.minimumLogLevel)
+	assert.True(t, initial.logArgs)
+
+	l.logCall(&Call{Ctx: context.TODO(), Op: "Ping", Start: time.Now()}, LevelDebug, nil)
+	assert.Len(t, bl.get(), 1)
+
+	ctrl.SetSampler(nil)
+	assert.Nil(t, l.options().sampler)
+}
+
+func TestController_RuntimeOptionsConcurrent(t *testing.T) {
+	ctrl := NewController(&syncTestLogger{})
+	l := ctrl.logger
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 100; j++ {
+				l.logCall(&Call{Ctx: context.TODO(), Op: "QueryContext", Start: time.Now(), Query: "SELECT 1", Args: nil}, LevelInfo, nil)
+			}
+		}()
+
+		go func(lvl Level) {
+			defer wg.Done()
+
+			for j := 0; j < 100; j++ {
+				ctrl.SetMinimumLevel(lvl)
+				ctrl.SetLogArguments(j%2 == 0)
+			}
+		}(Level(i))
+	}
+
+	wg.Wait()
+}
@@ -7,7 +7,33 @@ import (
 )
 
 // OpenDriver wrap given driver with logger and return *sql.DB.
+//
+// The logger is owned by returned *sql.DB, its asynchronous logger (see: WithAsyncLogger) and watchdog
+// (see: WithWatchdog) are flushed and stopped by sql.DB.Close().
 func OpenDriver(dsn string, drv driver.Driver, lg Logger, opt ...Option) *sql.DB {
+	ctl := NewController(lg, opt...)
+
+	return sql.OpenDB(&connector{dsn: dsn, driver: drv, logger: ctl.logger, owner: ctl})
+}
+
+// OpenConnector wrap given driver.Connector with logger and return *sql.DB.
+//
+// Use this when driver.Connector is built programmatically (e.g: mysql.NewConnector(cfg)).
+// The logger is owned by returned *sql.DB, it is stopped by sql.DB.Close() (see: OpenDriver).
+func OpenConnector(c driver.Connector, lg Logger, opt ...Option) *sql.DB {
+	return sql.OpenDB(NewConnector(c, lg, opt...))
+}
+
+// NewConnector wrap given driver.Connector with logger and return wrapped driver.Connector.
+// The logger is owned by returned driver.Connector, it is stopped by its Close() (see: OpenDriver).
+func NewConnector(c driver.Connector, lg Logger, opt ...Option) driver.Connector {
+	ctl := NewController(lg, opt...)
+
+	return &connector{connector: c, logger: ctl.logger, owner: ctl}
+}
+
+// newLogger create internal logger wrapper with default options overridden by given options.
+func newLogger(lg Logger, opt ...Option) *logger {
 	opts := &options{}
 	setDefaultOptions(opts)
 
@@ -15,11 +41,15 @@ func OpenDriver(dsn string, drv driver.Driver, lg Logger, opt ...Option) *sql.DB
 		o(opts)
 	}
 
-	conn := &connector{
-		dsn:    dsn,
-		driver: drv,
-		logger: &logger{logger: lg, opt: opts},
+	if opts.asyncBufferSize > 0 {
+		lg = newAsyncLogger(lg, opts.asyncBufferSize, opts.asyncDropPolicy)
+	}
+
+	l := &logger{logger: lg, opt: opts}
+
+	if opts.watchdogThreshold > 0 {
+		l.watchdog = newWatchdog(l, opts.watchdogThreshold, opts.watchdogInterval)
 	}
 
-	return sql.OpenDB(conn)
+	return l
 }
@@ -43,6 +43,35 @@ func TestOpenDriver(t *testing.T) {
 	})
 }
 
+func TestOpenConnector(t *testing.T) {
+	mockConnector := &connectorMock{}
+	mockConnector.On("Connect", mock.Anything).Return(&driverConnMock{}, driver.ErrBadConn)
+
+	db := OpenConnector(mockConnector, bufLogger, WithErrorFieldname("errtest"))
+	_, ok := interface{}(db).(*sql.DB)
+	assert.True(t, ok)
+	err := db.Ping()
+	assert.Error(t, err)
+
+	var output bufLog
+	err = json.Unmarshal(bufLogger.Bytes(), &output)
+	assert.NoError(t, err)
+	assert.Equal(t, "Connect", output.Message)
+	assert.Equal(t, LevelError.String(), output.Level)
+	assert.Contains(t, output.Data, "errtest")
+}
+
+func TestNewConnector(t *testing.T) {
+	mockConnector := &connectorMock{}
+	con := NewConnector(mockConnector, bufLogger, WithMinimumLevel(LevelInfo))
+	assert.Implements(t, (*driver.Connector)(nil), con)
+
+	wrapped, ok := con.(*connector)
+	assert.True(t, ok)
+	assert.Equal(t, mockConnector, wrapped.connector)
+	assert.Equal(t, LevelInfo, wrapped.logger.opt.minimumLogLevel)
+}
+
 type driverMock struct {
 	mock.Mock
 }
@@ -0,0 +1,184 @@
+package sqldblogger
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// eventArgsBufLen is number of arguments logged without allocating Event.Args.
+const eventArgsBufLen = 8
+
+// Event is a typed log event delivered to EventLogger.
+type Event struct {
+	// Op is operation name (e.g: "QueryContext", "StmtExec", "RowsClose", "Sampled").
+	Op string
+	// Message is log message, it is the query instead of Op if query logged as message (see: WithSQLQueryAsMessage).
+	Message string
+	Level   Level
+	// Time is time when the event logged.
+	Time time.Time
+	// Start is time when the call started.
+	Start time.Time
+	// Duration is total time spent on the call.
+	Duration time.Duration
+	// Query is logged query (masked if WithMaskSQLLiterals enabled), empty if none.
+	Query string
+	// Args is logged query arguments (redacted and truncated), nil if none or arguments logging disabled.
+	Args []interface{}
+	// NamedArgs is logged named query arguments (e.g: sql.Named()) by name, nil if none or arguments logging disabled.
+	NamedArgs map[string]interface{}
+	// Err is the call error, including error which is not logged on non error level (e.g: io.EOF on RowsNext).
+	Err    error
+	ConnID string
+	StmtID string
+	TxID   string
+	// RowsAffected is rows affected by Exec(Context), StmtExec(Context) and ResultRowsAffected,
+	// or total rows affected by transaction on Commit and Rollback, -1 if unknown.
+	RowsAffected int64
+	// Slow is true if the call took longer than slow query threshold (see: WithSlowQueryThreshold).
+	Slow bool
+	// Fingerprint and FingerprintHash of the query, empty if query fingerprint disabled (see: WithQueryFingerprint).
+	Fingerprint     string
+	FingerprintHash string
+	// Fields is other log data (e.g: rows and transaction summary, tags, context fields, interceptor fields), nil if none.
+	// Duration field (e.g: "tx_duration") is time.Duration, it is formatted by duration unit only in Data().
+	Fields map[string]interface{}
+	// opt is options of the logger which created the event, used by Data().
+	opt *options
+	// argsBuf is pooled backing array of Args.
+	argsBuf [eventArgsBufLen]interface{}
+}
+
+var eventPool = sync.Pool{
+	New: func() interface{} {
+		return &Event{}
+	},
+}
+
+// newEvent get an event from pool, it must be released after delivered (see: Event.release).
+func newEvent(opt *options) *Event {
+	e := eventPool.Get().(*Event)
+	e.RowsAffected, e.opt = -1, opt
+
+	return e
+}
+
+// release reset the event and put it back to pool.
+func (e *Event) release() {
+	*e = Event{}
+	eventPool.Put(e)
+}
+
+// EventLogger is optional Logger interface to receive typed Event instead of log data map.
+// Logger which implements it will receive every log via LogEvent(), and its Log() will not be called.
+//
+// The event is reused after LogEvent returns, so it must not be modified or retained (see: Event.Clone).
+type EventLogger interface {
+	LogEvent(ctx context.Context, e *Event)
+}
+
+// Data convert the event to Logger log data, as driver call log delivered to Logger which does not implement EventLogger.
+// Fieldnames and formats are the options of the logger which created the event, or default options.
+func (e *Event) Data() map[string]interface{} {
+	opt := e.opt
+	if opt == nil {
+		opt = &options{}
+		setDefaultOptions(opt)
+	}
+
+	data := make(map[string]interface{}, len(e.Fields)+8)
+
+	for k, v := range e.Fields {
+		if d, ok := v.(time.Duration); ok {
+			data[k] = opt.durationUnit.format(d)
+			continue
+		}
+
+		data[k] = v
+	}
+
+	if e.ConnID != "" {
+		data[opt.connIDFieldname] = e.ConnID
+	}
+
+	if e.TxID != "" {
+		data[opt.txIDFieldname] = e.TxID
+	}
+
+	if e.StmtID != "" {
+		data[opt.stmtIDFieldname] = e.StmtID
+	}
+
+	if e.Query != "" && !opt.sqlQueryAsMsg {
+		data[opt.sqlQueryFieldname] = e.Query
+	}
+
+	if opt.logArgs && e.Args != nil {
+		data[opt.sqlArgsFieldname] = append(make([]interface{}, 0, len(e.Args)), e.Args...)
+	}
+
+	data[opt.timeFieldname] = opt.timeFormat.format(e.Time)
+	data[opt.durationFieldname] = opt.durationUnit.format(e.Duration)
+
+	if opt.includeStartTime {
+		data[opt.startTimeFieldname] = opt.timeFormat.format(e.Start)
+	}
+
+	if e.Slow {
+		data[opt.slowQueryFieldname] = true
+	}
+
+	if e.Level >= LevelWarn && e.Err != nil {
+		data[opt.errorFieldname] = e.Err.Error()
+	}
+
+	if opt.queryFingerprint && e.Fingerprint != "" {
+		data[opt.fingerprintFieldname] = e.Fingerprint
+		data[opt.fingerprintHashFieldname] = e.FingerprintHash
+	}
+
+	return data
+}
+
+// Clone return a copy of the event which can be retained after LogEvent returns.
+func (e *Event) Clone() *Event {
+	c := *e
+	c.argsBuf = [eventArgsBufLen]interface{}{}
+
+	if e.Args != nil {
+		c.Args = append(make([]interface{}, 0, len(e.Args)), e.Args...)
+	}
+
+	return &c
+}
+
+// fields return event fields, create it if nil.
+func (e *Event) fields() map[string]interface{} {
+	if e.Fields == nil {
+		e.Fields = make(map[string]interface{})
+	}
+
+	return e.Fields
+}
+
+// eventLogger return EventLogger if Logger implements it, including Logger wrapped by asynchronous logger.
+func (l *logger) eventLogger() (EventLogger, bool) {
+	if a, ok := l.logger.(*asyncLogger); ok {
+		return a, a.events
+	}
+
+	el, ok := l.logger.(EventLogger)
+
+	return el, ok
+}
+
+// deliver the event to EventLogger, or to Logger as log data map.
+func (l *logger) deliver(ctx context.Context, e *Event) {
+	if el, ok := l.eventLogger(); ok {
+		el.LogEvent(ctx, e)
+		return
+	}
+
+	l.logger.Log(ctx, e.Level, e.Message, e.Data())
+}
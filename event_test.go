@@ -0,0 +1,209 @@
+package sqldblogger
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// eventTestLogger record delivered events, Log() must never be called.
+type eventTestLogger struct {
+	t      *testing.T
+	mu     sync.Mutex
+	events []Event
+}
+
+func (el *eventTestLogger) Log(_ context.Context, _ Level, msg string, _ map[string]interface{}) {
+	el.t.Errorf("Log() called on EventLogger: %s", msg)
+}
+
+func (el *eventTestLogger) LogEvent(_ context.Context, e *Event) {
+	el.mu.Lock()
+	defer el.mu.Unlock()
+
+	el.events = append(el.events, *e.Clone())
+}
+
+func (el *eventTestLogger) get() []Event {
+	el.mu.Lock()
+	defer el.mu.Unlock()
+
+	return append([]Event(nil), el.events...)
+}
+
+func (el *eventTestLogger) last() Event {
+	events := el.get()
+	if len(events) == 0 {
+		return Event{}
+	}
+
+	return events[len(events)-1]
+}
+
+func TestEventLogger_ExecContext(t *testing.T) {
+	el := &eventTestLogger{t: t}
+	l := newLogger(el)
+
+	driverConnMock := &driverConnExecerContextMock{}
+	driverConnMock.On("ExecContext", mock.Anything, mock.Anything, mock.Anything).Return(driver.RowsAffected(3), nil)
+
+	conn := &connection{Conn: driverConnMock, logger: l, id: "conn"}
+	_, err := conn.ExecContext(context.TODO(), "UPDATE tt SET name = ? WHERE id = :id", []driver.NamedValue{
+		{Ordinal: 1, Value: "name"},
+		{Name: "id", Ordinal: 2, Value: 1},
+	})
+	assert.NoError(t, err)
+
+	e := el.last()
+	assert.Equal(t, "ExecContext", e.Op)
+	assert.Equal(t, "ExecContext", e.Message)
+	assert.Equal(t, LevelInfo, e.Level)
+	assert.Equal(t, "UPDATE tt SET name = ? WHERE id = :id", e.Query)
+	assert.Equal(t, []interface{}{"name", 1}, e.Args)
+	assert.Equal(t, map[string]interface{}{"id": 1}, e.NamedArgs)
+	assert.Equal(t, int64(3), e.RowsAffected)
+	assert.Equal(t, "conn", e.ConnID)
+	assert.Empty(t, e.TxID)
+	assert.Empty(t, e.StmtID)
+	assert.NoError(t, e.Err)
+	assert.False(t, e.Start.IsZero())
+	assert.False(t, e.Time.Before(e.Start))
+	assert.Nil(t, e.Fields)
+}
+
+func TestEventLogger_Error(t *testing.T) {
+	el := &eventTestLogger{t: t}
+	l := newLogger(el, WithLogArguments(false))
+
+	driverConnMock := &driverConnQueryerContextMock{}
+	driverConnMock.On("QueryContext", mock.Anything, mock.Anything, mock.Anything).Return(&rowsMock{}, driver.ErrBadConn)
+
+	conn := &connection{Conn: driverConnMock, logger: l, id: "conn"}
+	_, err := conn.QueryContext(context.TODO(), "SELECT * FROM tt WHERE id = ?", []driver.NamedValue{{Name: "id", Ordinal: 1, Value: 1}})
+	assert.Error(t, err)
+
+	e := el.last()
+	assert.Equal(t, LevelError, e.Level)
+	assert.ErrorIs(t, e.Err, driver.ErrBadConn)
+	assert.Nil(t, e.Args)
+	assert.Nil(t, e.NamedArgs)
+	assert.Equal(t, int64(-1), e.RowsAffected)
+}
+
+func TestEventLogger_Transaction(t *testing.T) {
+	el := &eventTestLogger{t: t}
+	l := newLogger(el)
+
+	txMock := &transactionMock{}
+	txMock.On("Commit").Return(nil)
+
+	tx := &transaction{Tx: txMock, ctx: context.TODO(), logger: l, id: "tx", connID: "conn", begin: time.Now(), rowsAffected: 5}
+	assert.NoError(t, tx.Commit())
+
+	e := el.last()
+	assert.Equal(t, "Commit", e.Op)
+	assert.Equal(t, "tx", e.TxID)
+	assert.Equal(t, int64(5), e.RowsAffected)
+	assert.Equal(t, int64(5), e.Fields["tx_rows_affected"])
+	assert.IsType(t, time.Duration(0), e.Fields["tx_duration"])
+
+	data := e.Data()
+	assert.IsType(t, float64(0), data["tx_duration"])
+}
+
+func TestEventLogger_Async(t *testing.T) {
+	el := &eventTestLogger{t: t}
+	l := newLogger(el, WithAsyncLogger(10, DropPolicyBlock), WithSQLQueryAsMessage(true))
+
+	l.logCall(&Call{Ctx: WithTag(context.TODO(), "handler", "users"), Op: "QueryContext", Start: time.Now(), Query: "SELECT 1"}, LevelInfo, nil)
+	assert.NoError(t, l.logger.(*asyncLogger).Close())
+
+	e := el.last()
+	assert.Equal(t, "QueryContext", e.Op)
+	assert.Equal(t, "SELECT 1", e.Message)
+	assert.Equal(t, "SELECT 1", e.Query)
+	assert.Equal(t, map[string]interface{}{"handler": "users"}, e.Fields)
+}
+
+func TestEventLogger_Watchdog(t *testing.T) {
+	el := &eventTestLogger{t: t}
+	l := newLogger(el, WithWatchdog(10*time.Millisecond, 20*time.Millisecond))
+	defer l.watchdog.close()
+
+	unwatch := l.watch("QueryContext", "conn", "", "", "SELECT 1")
+	time.Sleep(40 * time.Millisecond)
+	unwatch()
+
+	e := el.last()
+	assert.Equal(t, "QueryContext", e.Op)
+	assert.Equal(t, LevelWarn, e.Level)
+	assert.Equal(t, "SELECT 1", e.Query)
+	assert.Equal(t, "conn", e.ConnID)
+	assert.GreaterOrEqual(t, e.Duration, 10*time.Millisecond)
+	assert.Equal(t, true, e.Fields["in_flight"])
+}
+
+func TestEvent_Data(t *testing.T) {
+	bl := &bufferTestLogger{}
+	el := &eventTestLogger{t: t}
+	opts := []Option{WithIncludeStartTime(true), WithQueryFingerprint(true), WithTimeFormat(TimeFormatRFC3339Nano)}
+	lb, le := newLogger(bl, opts...), newLogger(el, opts...)
+	start, err := time.Now(), errors.New("failed")
+
+	for _, l := range []*logger{lb, le} {
+		q := "SELECT * FROM tt WHERE id = ?"
+		call := &Call{Ctx: context.TODO(), Op: "StmtQueryContext", Start: start, Err: err, ConnID: "conn", StmtID: "stmt", Query: q, Args: valuesToNamedValues([]driver.Value{1})}
+		call.SetField("extra", "value")
+		l.logCall(call, LevelError, nil)
+	}
+
+	var content bufLog
+	assert.NoError(t, json.Unmarshal(bl.Bytes(), &content))
+
+	e := el.last()
+	b, _ := json.Marshal(bufLog{e.Level.String(), e.Message, e.Data()})
+
+	var data bufLog
+	assert.NoError(t, json.Unmarshal(b, &data))
+
+	// time and duration differ
+	for _, k := range []string{lb.opt.timeFieldname, lb.opt.durationFieldname} {
+		assert.Contains(t, data.Data, k)
+		delete(content.Data, k)
+		delete(data.Data, k)
+	}
+
+	assert.Equal(t, content, data)
+	assert.Equal(t, "value", data.Data["extra"])
+	assert.Equal(t, err.Error(), data.Data[lb.opt.errorFieldname])
+}
+
+func TestEvent_DataDefaultOptions(t *testing.T) {
+	e := &Event{Op: "Ping", Message: "Ping", Level: LevelDebug, ConnID: "conn", Duration: time.Second, Err: errors.New("not logged")}
+	data := e.Data()
+	assert.Equal(t, "conn", data["conn_id"])
+	assert.Equal(t, 1000.0, data["duration"])
+	assert.NotContains(t, data, "error")
+	assert.NotContains(t, data, "query")
+}
+
+func TestEvent_Clone(t *testing.T) {
+	e := newEvent(nil)
+	e.Args = append(e.argsBuf[:0], 1, "name")
+	e.Fields = map[string]interface{}{"k": "v"}
+
+	c := e.Clone()
+	e.release()
+
+	assert.Equal(t, []interface{}{1, "name"}, c.Args)
+	assert.Equal(t, map[string]interface{}{"k": "v"}, c.Fields)
+	assert.Equal(t, int64(-1), c.RowsAffected)
+	assert.Nil(t, e.Args)
+}
@@ -0,0 +1,150 @@
+package sqldblogger
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+)
+
+// FingerprintSQL normalize given SQL query for grouping similar queries:
+// comments removed, literals and placeholders replaced with "?", unquoted words lowercased,
+// "IN (?, ?, ...)" list collapsed to "in (?+)", and tokens separated by single space regardless of original spacing
+// (except around "(", ")", ",", "." and "::"), so "id=1" and "id = 2" have the same fingerprint "id = ?".
+// Unary sign of number is part of the literal, so "id = -1" also has the same fingerprint.
+func FingerprintSQL(query string, dialect SQLDialect) string {
+	var (
+		tokens []fingerprintToken
+		space  bool
+	)
+
+	scanSQL(query, dialect, func(kind sqlTokenKind, tok string) {
+		switch kind {
+		case sqlTokenSpace, sqlTokenComment:
+			space = true
+			return
+		case sqlTokenString, sqlTokenNumber, sqlTokenPlaceholder:
+			if kind == sqlTokenNumber && unarySign(tokens) {
+				tokens = tokens[:len(tokens)-1]
+			}
+
+			tok = "?"
+		case sqlTokenWord:
+			tok = strings.ToLower(tok)
+		case sqlTokenOther:
+			// join multi-character operator (e.g: ">=", "::") which is tokenized per character.
+			if n := len(tokens); n > 0 && !space && tokens[n-1].operator && sqlOperators[tokens[n-1].text+tok] {
+				tokens[n-1].text += tok
+				return
+			}
+		}
+
+		tokens = append(tokens, fingerprintToken{text: tok, word: kind == sqlTokenWord, operator: kind == sqlTokenOther})
+		space = false
+	})
+
+	var b strings.Builder
+
+	b.Grow(len(query))
+
+	for i := 0; i < len(tokens); i++ {
+		if i > 0 && fingerprintSpace(tokens[i-1], tokens[i]) {
+			b.WriteByte(' ')
+		}
+
+		b.WriteString(tokens[i].text)
+
+		if tokens[i].text != "in" {
+			continue
+		}
+
+		if end := inListEnd(tokens, i+1); end > 0 {
+			b.WriteString(" (?+)")
+			i = end
+		}
+	}
+
+	return b.String()
+}
+
+// FingerprintHash return stable short hash (16 hex characters of 64-bit FNV-1a) of given fingerprint.
+func FingerprintHash(fingerprint string) string {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(fingerprint))
+
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
+// fingerprintToken is normalized token.
+type fingerprintToken struct {
+	text     string
+	word     bool
+	operator bool
+}
+
+// sqlOperators is multi-character operators joined in fingerprint.
+var sqlOperators = map[string]bool{
+	"<=": true, ">=": true, "<>": true, "!=": true, "::": true, "||": true, ":=": true, "=>": true,
+	"->": true, "->>": true, "#>": true, "#>>": true, "@>": true, "<@": true, "&&": true, "<<": true, ">>": true,
+}
+
+// fingerprintKeywords is keywords followed by space before "(", other words followed by "(" are function calls.
+var fingerprintKeywords = map[string]bool{
+	"all": true, "and": true, "any": true, "as": true, "exists": true, "from": true, "in": true, "into": true,
+	"join": true, "not": true, "on": true, "or": true, "over": true, "select": true, "some": true, "table": true,
+	"using": true, "values": true, "where": true, "with": true,
+}
+
+// fingerprintSpace return true if normalized tokens prev and next are separated by space.
+func fingerprintSpace(prev, next fingerprintToken) bool {
+	switch {
+	case prev.text == "(", prev.text == ".", prev.text == "::":
+		return false
+	case next.text == ")", next.text == ",", next.text == ".", next.text == "::", next.text == ";":
+		return false
+	case next.text == "(":
+		return !prev.word || fingerprintKeywords[prev.text]
+	}
+
+	return true
+}
+
+// unarySign return true if the last token is "-" or "+" sign of following number: it is the first token,
+// or it follows an operator (except ")" and "]"), "(", "," or a keyword (e.g: "select -1", "in (-1, +2)").
+func unarySign(tokens []fingerprintToken) bool {
+	n := len(tokens)
+	if n == 0 || !tokens[n-1].operator || (tokens[n-1].text != "-" && tokens[n-1].text != "+") {
+		return false
+	}
+
+	if n == 1 {
+		return true
+	}
+
+	prev := tokens[n-2]
+
+	return prev.operator && prev.text != ")" && prev.text != "]" || prev.word && fingerprintKeywords[prev.text]
+}
+
+// inListEnd return position of closing parenthesis if tokens from pos is "(?, ?, ...)", otherwise 0.
+func inListEnd(tokens []fingerprintToken, pos int) int {
+	if pos >= len(tokens) || tokens[pos].text != "(" {
+		return 0
+	}
+
+	for i := pos + 1; i+1 < len(tokens); i += 2 {
+		if tokens[i].text != "?" {
+			return 0
+		}
+
+		switch tokens[i+1].text {
+		case ")":
+			return i + 1
+		case ",":
+			continue
+		default:
+			return 0
+		}
+	}
+
+	return 0
+}
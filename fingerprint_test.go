@@ -0,0 +1,104 @@
+package sqldblogger
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFingerprintSQL(t *testing.T) {
+	tt := []struct {
+		name    string
+		dialect SQLDialect
+		query   string
+		expect  string
+	}{
+		{
+			name:   "literals and whitespace",
+			query:  "SELECT *\n\tFROM  users WHERE email = 'x@y.com'   AND age > 21",
+			expect: "select * from users where email = ? and age > ?",
+		},
+		{
+			name:   "in list",
+			query:  "SELECT * FROM t WHERE id IN (1,2,3) AND b in ( 'a' , 'b' )",
+			expect: "select * from t where id in (?+) and b in (?+)",
+		},
+		{
+			name:    "in list placeholders",
+			dialect: SQLDialectPostgres,
+			query:   "SELECT * FROM t WHERE id IN ($1, $2)",
+			expect:  "select * from t where id in (?+)",
+		},
+		{
+			name:   "in subquery kept",
+			query:  "SELECT * FROM t WHERE id IN (SELECT id FROM u WHERE x = 1)",
+			expect: "select * from t where id in (select id from u where x = ?)",
+		},
+		{
+			name:   "comments removed",
+			query:  "/* app=api */ SELECT 1 -- trailing",
+			expect: "select ?",
+		},
+		{
+			name:   "spacing normalized",
+			query:  "SELECT t.a,COUNT( * ) FROM t WHERE a>=1 AND b::text<>'x'AND c IN(SELECT 1) GROUP BY t.a ;",
+			expect: "select t.a, count(*) from t where a >= ? and b::text <> ? and c in (select ?) group by t.a;",
+		},
+		{
+			name:   "unary sign",
+			query:  "SELECT -1, +2 FROM t WHERE x = -5 AND y IN (-1, - 2) AND z>-3",
+			expect: "select ?, ? from t where x = ? and y in (?+) and z > ?",
+		},
+		{
+			name:   "binary sign kept",
+			query:  "SELECT a-1, a - 2, (a) + 3, ? - 4 FROM t",
+			expect: "select a - ?, a - ?, (a) + ?, ? - ? from t",
+		},
+		{
+			name:   "quoted identifier kept",
+			query:  `SELECT "Name" FROM "Users"`,
+			expect: `select "Name" from "Users"`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expect, FingerprintSQL(tc.query, tc.dialect))
+		})
+	}
+
+	for _, pair := range [][2]string{
+		{"SELECT * FROM t WHERE id IN (1,2,3)", "select * from t where id in (4, 5)"},
+		{"SELECT * FROM t WHERE id=1", "SELECT * FROM t WHERE id = 2"},
+		{"SELECT * FROM t WHERE id IN (1,2)", "SELECT * FROM t WHERE id IN(4, 5)"},
+		{"INSERT INTO t(a,b) VALUES(1,2)", "INSERT INTO t (a, b) VALUES (3, 4)"},
+	} {
+		assert.Equal(t, FingerprintSQL(pair[0], SQLDialectGeneric), FingerprintSQL(pair[1], SQLDialectGeneric), pair[0])
+	}
+}
+
+func TestFingerprintHash(t *testing.T) {
+	h := FingerprintHash("select ?")
+	assert.Len(t, h, 16)
+	assert.Equal(t, h, FingerprintHash("select ?"))
+	assert.NotEqual(t, h, FingerprintHash("select ? from t"))
+}
+
+func TestLogInternalWithQueryFingerprint(t *testing.T) {
+	cfg := &options{}
+	setDefaultOptions(cfg)
+	WithQueryFingerprint(true)(cfg)
+	bl := &bufferTestLogger{}
+	l := &logger{opt: cfg, logger: bl}
+	l.logCall(&Call{Ctx: context.TODO(), Op: "msg", Start: time.Now(), Query: "SELECT * FROM t WHERE id IN (1, 2)"}, LevelInfo, nil)
+
+	var content bufLog
+	err := json.Unmarshal(bl.Bytes(), &content)
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM t WHERE id IN (1, 2)", content.Data[cfg.sqlQueryFieldname])
+	assert.Equal(t, "select * from t where id in (?+)", content.Data[cfg.fingerprintFieldname])
+	assert.Equal(t, FingerprintHash("select * from t where id in (?+)"), content.Data[cfg.fingerprintHashFieldname])
+}
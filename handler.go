@@ -0,0 +1,279 @@
+package sqldblogger
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// HandlerConfig is logger configuration exposed and changed by Controller.Handler().
+type HandlerConfig struct {
+	MinimumLevel       string `json:"minimum_level"`
+	PreparerLevel      string `json:"preparer_level"`
+	QueryerLevel       string `json:"queryer_level"`
+	ExecerLevel        string `json:"execer_level"`
+	LogArguments       bool   `json:"log_arguments"`
+	SlowQueryThreshold string `json:"slow_query_threshold"`
+	SlowQueryLevel     string `json:"slow_query_level"`
+	Sampling           bool   `json:"sampling"`
+	// RevertAt is time when next temporary change will be reverted, empty if none.
+	RevertAt string `json:"revert_at,omitempty"`
+}
+
+// HandlerChange is PUT/POST request body of Controller.Handler(), unset field will not be changed.
+type HandlerChange struct {
+	MinimumLevel       *string `json:"minimum_level"`
+	LogArguments       *bool   `json:"log_arguments"`
+	SlowQueryThreshold *string `json:"slow_query_threshold"`
+	SlowQueryLevel     *string `json:"slow_query_level"`
+	// TTL revert the change after given duration (e.g: "5m"), empty means permanent change.
+	TTL string `json:"ttl"`
+}
+
+// controllerHandler is http.Handler to inspect and change logger configuration at runtime.
+type controllerHandler struct {
+	ctrl *Controller
+	// mu guard pending reverts.
+	mu sync.Mutex
+	// reverts is pending revert of temporary changed fields.
+	reverts map[handlerField]*handlerRevert
+}
+
+// handlerField is configuration field group changed by HandlerChange.
+type handlerField uint8
+
+const (
+	handlerFieldMinimumLevel handlerField = iota
+	handlerFieldLogArguments
+	// handlerFieldSlowQuery is slow query threshold and level, they are set by single option.
+	handlerFieldSlowQuery
+)
+
+// handlerRevert is pending revert of a temporary changed field.
+type handlerRevert struct {
+	// restore is option which restore the field value before first temporary change.
+	restore Option
+	at      time.Time
+	timer   *time.Timer
+}
+
+// Handler return http.Handler which expose current logger configuration as JSON on GET,
+// and change minimum level, arguments logging and slow query threshold on PUT/POST (see: HandlerChange).
+//
+// Change with TTL is reverted after given TTL, later change without TTL makes it permanent.
+// Revert is tracked per changed field, so later change only affect pending revert of the fields it sets.
+// The handler has no authentication, it is meant to be mounted on internal admin port.
+func (c *Controller) Handler() http.Handler {
+	return &controllerHandler{ctrl: c, reverts: make(map[handlerField]*handlerRevert)}
+}
+
+// ServeHTTP implement http.Handler.
+func (h *controllerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+	case http.MethodPut, http.MethodPost:
+		var change HandlerChange
+
+		if err := json.NewDecoder(r.Body).Decode(&change); err != nil {
+			h.writeError(w, fmt.Errorf("invalid request body: %w", err))
+			return
+		}
+
+		if err := h.apply(change); err != nil {
+			h.writeError(w, err)
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, HEAD, PUT, POST")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(h.config())
+}
+
+func (h *controllerHandler) writeError(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
+
+// config return current configuration.
+func (h *controllerHandler) config() HandlerConfig {
+	opt := h.ctrl.logger.options()
+	cfg := HandlerConfig{
+		MinimumLevel:       opt.minimumLogLevel.String(),
+		PreparerLevel:      opt.preparerLevel.String(),
+		QueryerLevel:       opt.queryerLevel.String(),
+		ExecerLevel:        opt.execerLevel.String(),
+		LogArguments:       opt.logArgs,
+		SlowQueryThreshold: opt.slowQueryThreshold.String(),
+		SlowQueryLevel:     opt.slowQueryLevel.String(),
+		Sampling:           opt.sampler != nil,
+	}
+
+	var next time.Time
+
+	h.mu.Lock()
+	for _, r := range h.reverts {
+		if next.IsZero() || r.at.Before(next) {
+			next = r.at
+		}
+	}
+	h.mu.Unlock()
+
+	if !next.IsZero() {
+		cfg.RevertAt = next.Format(time.RFC3339)
+	}
+
+	return cfg
+}
+
+// apply validate and apply given change, then schedule or cancel revert of changed fields.
+func (h *controllerHandler) apply(change HandlerChange) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	opts, err := change.options(h.ctrl.logger.options())
+	if err != nil {
+		return err
+	}
+
+	var ttl time.Duration
+
+	if change.TTL != "" {
+		if ttl, err = time.ParseDuration(change.TTL); err != nil || ttl <= 0 {
+			return fmt.Errorf("invalid ttl: %q", change.TTL)
+		}
+	}
+
+	current := h.ctrl.logger.options()
+
+	for _, f := range change.fields() {
+		prev := h.reverts[f]
+		if prev != nil {
+			prev.timer.Stop()
+			delete(h.reverts, f)
+		}
+
+		if ttl <= 0 {
+			continue
+		}
+
+		r := &handlerRevert{restore: f.option(current), at: time.Now().Add(ttl)}
+		if prev != nil {
+			r.restore = prev.restore
+		}
+
+		f := f
+		r.timer = time.AfterFunc(ttl, func() { h.revertField(f, r) })
+		h.reverts[f] = r
+	}
+
+	h.ctrl.update(opts...)
+
+	return nil
+}
+
+// revertField restore given field changed by temporary change, unless the revert is no longer pending.
+func (h *controllerHandler) revertField(f handlerField, r *handlerRevert) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.reverts[f] != r {
+		return
+	}
+
+	delete(h.reverts, f)
+	h.ctrl.update(r.restore)
+}
+
+// option return option which set the field to given options value.
+func (f handlerField) option(opt *options) Option {
+	switch f {
+	case handlerFieldMinimumLevel:
+		return WithMinimumLevel(opt.minimumLogLevel)
+	case handlerFieldLogArguments:
+		return WithLogArguments(opt.logArgs)
+	default:
+		return WithSlowQueryThreshold(opt.slowQueryThreshold, opt.slowQueryLevel)
+	}
+}
+
+// fields return configuration fields set by the change.
+func (c HandlerChange) fields() []handlerField {
+	var fields []handlerField
+
+	if c.MinimumLevel != nil {
+		fields = append(fields, handlerFieldMinimumLevel)
+	}
+
+	if c.LogArguments != nil {
+		fields = append(fields, handlerFieldLogArguments)
+	}
+
+	if c.SlowQueryThreshold != nil || c.SlowQueryLevel != nil {
+		fields = append(fields, handlerFieldSlowQuery)
+	}
+
+	return fields
+}
+
+// options convert change to options, unset slow query threshold or level will use the current one.
+func (c HandlerChange) options(current *options) ([]Option, error) {
+	var opts []Option
+
+	if c.MinimumLevel != nil {
+		lvl, err := parseLevel(*c.MinimumLevel)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, WithMinimumLevel(lvl))
+	}
+
+	if c.LogArguments != nil {
+		opts = append(opts, WithLogArguments(*c.LogArguments))
+	}
+
+	if c.SlowQueryThreshold != nil || c.SlowQueryLevel != nil {
+		threshold, lvl := current.slowQueryThreshold, current.slowQueryLevel
+
+		if c.SlowQueryThreshold != nil {
+			d, err := time.ParseDuration(*c.SlowQueryThreshold)
+			if err != nil {
+				return nil, fmt.Errorf("invalid slow_query_threshold: %q", *c.SlowQueryThreshold)
+			}
+
+			threshold = d
+		}
+
+		if c.SlowQueryLevel != nil {
+			l, err := parseLevel(*c.SlowQueryLevel)
+			if err != nil {
+				return nil, err
+			}
+
+			lvl = l
+		}
+
+		opts = append(opts, WithSlowQueryThreshold(threshold, lvl))
+	}
+
+	return opts, nil
+}
+
+// parseLevel convert level string (see: Level.String()) to Level.
+func parseLevel(s string) (Level, error) {
+	for lvl := LevelTrace; lvl <= LevelError; lvl++ {
+		if lvl.String() == s {
+			return lvl, nil
+		}
+	}
+
+	return LevelTrace, fmt.Errorf("invalid level: %q", s)
+}
@@ -0,0 +1,138 @@
+package sqldblogger
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func serveHandler(t *testing.T, h http.Handler, method, body string) (int, HandlerConfig) {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(method, "/", strings.NewReader(body)))
+
+	var cfg HandlerConfig
+	if rec.Code == http.StatusOK {
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &cfg))
+	}
+
+	return rec.Code, cfg
+}
+
+func TestController_Handler(t *testing.T) {
+	ctrl := NewController(&syncTestLogger{}, WithMinimumLevel(LevelInfo))
+	h := ctrl.Handler()
+
+	code, cfg := serveHandler(t, h, http.MethodGet, "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, HandlerConfig{
+		MinimumLevel:       "info",
+		PreparerLevel:      "info",
+		QueryerLevel:       "info",
+		ExecerLevel:        "info",
+		LogArguments:       true,
+		SlowQueryThreshold: "0s",
+		SlowQueryLevel:     "info",
+	}, cfg)
+
+	code, cfg = serveHandler(t, h, http.MethodPut, `{"minimum_level":"trace","log_arguments":false,"slow_query_threshold":"500ms"}`)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "trace", cfg.MinimumLevel)
+	assert.False(t, cfg.LogArguments)
+	assert.Equal(t, "500ms", cfg.SlowQueryThreshold)
+	assert.Equal(t, "info", cfg.SlowQueryLevel)
+	assert.Empty(t, cfg.RevertAt)
+	assert.Equal(t, LevelTrace, ctrl.logger.options().minimumLogLevel)
+
+	code, cfg = serveHandler(t, h, http.MethodPost, `{"slow_query_level":"error"}`)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "500ms", cfg.SlowQueryThreshold)
+	assert.Equal(t, "error", cfg.SlowQueryLevel)
+
+	code, _ = serveHandler(t, h, http.MethodDelete, "")
+	assert.Equal(t, http.StatusMethodNotAllowed, code)
+
+	for _, body := range []string{
+		`{`,
+		`{"minimum_level":"verbose"}`,
+		`{"slow_query_threshold":"fast"}`,
+		`{"slow_query_level":"warning"}`,
+		`{"minimum_level":"debug","ttl":"-1m"}`,
+	} {
+		code, _ = serveHandler(t, h, http.MethodPut, body)
+		assert.Equal(t, http.StatusBadRequest, code, body)
+	}
+
+	// invalid change is not applied
+	assert.Equal(t, LevelTrace, ctrl.logger.options().minimumLogLevel)
+}
+
+func TestController_HandlerTTL(t *testing.T) {
+	ctrl := NewController(&syncTestLogger{}, WithMinimumLevel(LevelInfo))
+	h := ctrl.Handler()
+
+	code, cfg := serveHandler(t, h, http.MethodPut, `{"minimum_level":"trace","log_arguments":false,"ttl":"20ms"}`)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "trace", cfg.MinimumLevel)
+	assert.NotEmpty(t, cfg.RevertAt)
+
+	// another temporary change extend the revert, original options is restored
+	code, _ = serveHandler(t, h, http.MethodPut, `{"minimum_level":"debug","ttl":"30ms"}`)
+	assert.Equal(t, http.StatusOK, code)
+
+	assert.Eventually(t, func() bool {
+		_, cfg = serveHandler(t, h, http.MethodGet, "")
+		return cfg.RevertAt == ""
+	}, time.Second, 5*time.Millisecond)
+
+	assert.Equal(t, "info", cfg.MinimumLevel)
+	assert.True(t, cfg.LogArguments)
+
+	// permanent change cancel pending revert
+	serveHandler(t, h, http.MethodPut, `{"minimum_level":"trace","ttl":"10ms"}`)
+	serveHandler(t, h, http.MethodPut, `{"minimum_level":"error"}`)
+	time.Sleep(30 * time.Millisecond)
+
+	_, cfg = serveHandler(t, h, http.MethodGet, "")
+	assert.Equal(t, "error", cfg.MinimumLevel)
+	assert.Empty(t, cfg.RevertAt)
+}
+
+func TestController_HandlerTTLOtherField(t *testing.T) {
+	ctrl := NewController(&syncTestLogger{}, WithMinimumLevel(LevelInfo))
+	h := ctrl.Handler()
+
+	serveHandler(t, h, http.MethodPut, `{"minimum_level":"trace","ttl":"20ms"}`)
+
+	// change of other field does not cancel pending revert
+	code, cfg := serveHandler(t, h, http.MethodPut, `{"slow_query_threshold":"1s"}`)
+	assert.Equal(t, http.StatusOK, code)
+	assert.NotEmpty(t, cfg.RevertAt)
+
+	assert.Eventually(t, func() bool {
+		_, cfg = serveHandler(t, h, http.MethodGet, "")
+		return cfg.RevertAt == ""
+	}, time.Second, 5*time.Millisecond)
+
+	assert.Equal(t, "info", cfg.MinimumLevel)
+	assert.Equal(t, "1s", cfg.SlowQueryThreshold)
+
+	// each temporary changed field is reverted by its own ttl
+	serveHandler(t, h, http.MethodPut, `{"minimum_level":"trace","ttl":"10ms"}`)
+	serveHandler(t, h, http.MethodPut, `{"log_arguments":false,"ttl":"1h"}`)
+
+	assert.Eventually(t, func() bool {
+		return ctrl.logger.options().minimumLogLevel == LevelInfo
+	}, time.Second, 5*time.Millisecond)
+
+	_, cfg = serveHandler(t, h, http.MethodGet, "")
+	assert.False(t, cfg.LogArguments)
+	assert.NotEmpty(t, cfg.RevertAt)
+}
@@ -0,0 +1,188 @@
+package sqldblogger
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrInterceptorNoResult is returned when interceptors chain return nil error without driver result,
+// e.g: interceptor return nil without calling next on a call which return driver.Rows.
+var ErrInterceptorNoResult = errors.New("sqldblogger: interceptor returned no result")
+
+// Call is a driver call descriptor passed through interceptors (see: WithInterceptors).
+// It is reused after the driver call logged, so interceptor must not retain it after returning.
+type Call struct {
+	// Ctx is context of the call, context.Background() for driver method without context.
+	// Interceptor may replace it before calling next, it is passed to the driver and Logger.
+	// Transaction, statement and rows created by the call keep the original call context.
+	Ctx context.Context
+	// Op is operation name which is also the log message (e.g: "QueryContext", "StmtExec", "Commit", "RowsNext").
+	Op string
+	// Query is SQL query of the call (if any), interceptor may rewrite it before calling next.
+	// Rewriting prepared statement query (and its rows and result) only change the query passed to next interceptors.
+	Query string
+	// Args is query arguments of the call (if any), interceptor may rewrite it before calling next.
+	Args   []driver.NamedValue
+	ConnID string
+	StmtID string
+	TxID   string
+	// Start is time when interceptors chain started.
+	Start time.Time
+	// Duration is time spent on actual driver call, zero if the call is short-circuited.
+	Duration time.Duration
+	// Err is the call error, set after the chain finished.
+	Err error
+	// Result is driver.Result of Exec(Context) and StmtExec(Context), set after next returns.
+	Result driver.Result
+	// Rows is driver.Rows of Query(Context) and StmtQuery(Context), set after next returns.
+	Rows   driver.Rows
+	fields map[string]interface{}
+
+	logger *logger
+	// invoker do the actual driver call after the last interceptor.
+	invoker invoker
+	// chain is interceptors chain, pos is index of next interceptor to call.
+	chain []Interceptor
+	pos   int
+	// nextFn is next() method value, it is created once per pooled call.
+	nextFn func() error
+
+	// driver call input and output which are not part of interceptor API.
+	txOpts driver.TxOptions
+	dest   []driver.Value
+	conn   driver.Conn
+	tx     driver.Tx
+	stmt   driver.Stmt
+	num    int64
+}
+
+// SetField attach a field to the call log data, it will not override sqldblogger fields.
+func (c *Call) SetField(key string, value interface{}) {
+	if c.fields == nil {
+		c.fields = make(map[string]interface{})
+	}
+
+	c.fields[key] = value
+}
+
+// Interceptor intercept a driver call. It must call next to continue the chain (and eventually the driver call),
+// or return a non-nil error without calling next to short-circuit the call.
+// Returning nil without calling next on a call which need driver result (connection, transaction, statement,
+// rows or result) fail the call with ErrInterceptorNoResult.
+// Error returned by interceptor is the call error returned to database/sql and logged.
+//
+// Interceptor must be safe for concurrent use.
+type Interceptor func(call *Call, next func() error) error
+
+// invoker do the actual driver call of given call operation, it is implemented by driver wrappers.
+type invoker interface {
+	invoke(call *Call) error
+}
+
+var callPool = sync.Pool{
+	New: func() interface{} {
+		c := &Call{}
+		c.nextFn = c.next
+
+		return c
+	},
+}
+
+// newCall get a call from pool, it must be released after logged (see: Call.release).
+func (l *logger) newCall(ctx context.Context, op string, inv invoker) *Call {
+	c := callPool.Get().(*Call)
+	c.Ctx, c.Op, c.logger, c.invoker = ctx, op, l, inv
+
+	return c
+}
+
+// release reset the call and put it back to pool.
+func (c *Call) release() {
+	nextFn := c.nextFn
+	*c = Call{nextFn: nextFn}
+	callPool.Put(c)
+}
+
+// intercept run the call through interceptors chain, the last next do the actual driver call.
+func (l *logger) intercept(call *Call) error {
+	call.chain = l.options().interceptors
+	call.Start = time.Now()
+	call.Err = call.next()
+
+	if call.Err == nil && len(call.chain) > 0 && call.noResult() {
+		call.Err = ErrInterceptorNoResult
+	}
+
+	return call.Err
+}
+
+// noResult check if the call operation expect driver result which is not set.
+func (c *Call) noResult() bool {
+	switch c.Op {
+	case "Connect":
+		return c.conn == nil
+	case "Begin", "BeginTx":
+		return c.tx == nil
+	case "Prepare", "PrepareContext":
+		return c.stmt == nil
+	case "Query", "QueryContext", "StmtQuery", "StmtQueryContext":
+		return c.Rows == nil
+	case "Exec", "ExecContext", "StmtExec", "StmtExecContext":
+		return c.Result == nil
+	}
+
+	return false
+}
+
+// next call next interceptor, or the driver if there is no more interceptor.
+// It can be called multiple times by the same interceptor (e.g: retry).
+func (c *Call) next() error {
+	if c.pos < len(c.chain) {
+		i := c.chain[c.pos]
+		c.pos++
+		err := i(c, c.nextFn)
+		c.pos--
+
+		return err
+	}
+
+	unwatch := c.logger.watchCall(c)
+	start := time.Now()
+	err := c.invoker.invoke(c)
+	c.Duration = time.Since(start)
+	unwatch()
+
+	return err
+}
+
+// watchedOps is operations tracked by watchdog.
+var watchedOps = map[string]bool{
+	"Connect":          true,
+	"Begin":            true,
+	"BeginTx":          true,
+	"Prepare":          true,
+	"PrepareContext":   true,
+	"Ping":             true,
+	"Exec":             true,
+	"ExecContext":      true,
+	"Query":            true,
+	"QueryContext":     true,
+	"StmtExec":         true,
+	"StmtExecContext":  true,
+	"StmtQuery":        true,
+	"StmtQueryContext": true,
+	"Commit":           true,
+	"Rollback":         true,
+}
+
+// watchCall track in-flight driver call by watchdog (if enabled) until returned func called.
+func (l *logger) watchCall(call *Call) func() {
+	if l.watchdog == nil || !watchedOps[call.Op] {
+		return noopUnwatch
+	}
+
+	return l.watch(call.Op, call.ConnID, call.TxID, call.StmtID, call.Query)
+}
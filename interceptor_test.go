@@ -0,0 +1,259 @@
+package sqldblogger
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// interceptorTestLogger create test logger with given interceptors.
+func interceptorTestLogger(bl *bufferTestLogger, interceptors ...Interceptor) *logger {
+	cfg := &options{}
+	setDefaultOptions(cfg)
+	cfg.minimumLogLevel = LevelTrace
+	WithInterceptors(interceptors...)(cfg)
+
+	return &logger{logger: bl, opt: cfg}
+}
+
+func TestInterceptor_Rewrite(t *testing.T) {
+	bl := &bufferTestLogger{}
+	l := interceptorTestLogger(bl, func(call *Call, next func() error) error {
+		call.Query += " LIMIT 1"
+		call.Args = append(call.Args, driver.NamedValue{Ordinal: len(call.Args) + 1, Value: "tenant"})
+
+		return next()
+	})
+
+	driverConnMock := &driverConnExecerContextMock{}
+	driverConnMock.On("ExecContext", mock.Anything, "SELECT * FROM tt WHERE id = ? LIMIT 1", []driver.NamedValue{
+		{Ordinal: 1, Value: 1},
+		{Ordinal: 2, Value: "tenant"},
+	}).Return(driver.ResultNoRows, nil)
+
+	conn := &connection{Conn: driverConnMock, logger: l, id: "conn"}
+	_, err := conn.ExecContext(context.TODO(), "SELECT * FROM tt WHERE id = ?", []driver.NamedValue{{Ordinal: 1, Value: 1}})
+	assert.NoError(t, err)
+	driverConnMock.AssertExpectations(t)
+
+	var output bufLog
+	assert.NoError(t, json.Unmarshal(bl.Bytes(), &output))
+	assert.Equal(t, "SELECT * FROM tt WHERE id = ? LIMIT 1", output.Data[l.opt.sqlQueryFieldname])
+	assert.Equal(t, []interface{}{float64(1), "tenant"}, output.Data[l.opt.sqlArgsFieldname])
+}
+
+func TestInterceptor_RewriteLegacyArgs(t *testing.T) {
+	l := interceptorTestLogger(&bufferTestLogger{}, func(call *Call, next func() error) error {
+		call.Args[0].Value = 2
+
+		return next()
+	})
+
+	stmtMock := &statementMock{}
+	stmtMock.On("Exec", []driver.Value{2}).Return(driver.ResultNoRows, nil)
+
+	stmt := &statement{Stmt: stmtMock, ctx: context.TODO(), query: "DELETE FROM tt WHERE id = ?", logger: l, id: "stmt", connID: "conn"}
+	_, err := stmt.Exec([]driver.Value{1}) // nolint // disable static check on deprecated driver method
+	assert.NoError(t, err)
+	stmtMock.AssertExpectations(t)
+}
+
+func TestInterceptor_ShortCircuit(t *testing.T) {
+	errDenied := errors.New("denied")
+	bl := &bufferTestLogger{}
+	l := interceptorTestLogger(bl, func(call *Call, next func() error) error {
+		if call.Op == "QueryContext" {
+			return errDenied
+		}
+
+		return next()
+	})
+
+	driverConnMock := &driverConnQueryerContextMock{}
+	conn := &connection{Conn: driverConnMock, logger: l, id: "conn"}
+	_, err := conn.QueryContext(context.TODO(), "SELECT 1", nil)
+	assert.ErrorIs(t, err, errDenied)
+	driverConnMock.AssertNotCalled(t, "QueryContext", mock.Anything, mock.Anything, mock.Anything)
+
+	var output bufLog
+	assert.NoError(t, json.Unmarshal(bl.Bytes(), &output))
+	assert.Equal(t, "QueryContext", output.Message)
+	assert.Equal(t, LevelError.String(), output.Level)
+	assert.Equal(t, errDenied.Error(), output.Data[l.opt.errorFieldname])
+}
+
+func TestInterceptor_SetField(t *testing.T) {
+	bl := &bufferTestLogger{}
+	l := interceptorTestLogger(bl, func(call *Call, next func() error) error {
+		call.SetField("shard", "db-1")
+		call.SetField("conn_id", "not overridden")
+		call.SetField("query", "not overridden")
+
+		return next()
+	})
+
+	driverConnMock := &driverConnPingerMock{}
+	driverConnMock.On("Ping").Return(nil)
+
+	conn := &connection{Conn: driverConnMock, logger: l, id: "conn"}
+	assert.NoError(t, conn.Ping(context.TODO()))
+
+	var output bufLog
+	assert.NoError(t, json.Unmarshal(bl.Bytes(), &output))
+	assert.Equal(t, "db-1", output.Data["shard"])
+	assert.Equal(t, "conn", output.Data[l.opt.connIDFieldname])
+	assert.NotContains(t, output.Data, l.opt.sqlQueryFieldname)
+}
+
+func TestInterceptor_Order(t *testing.T) {
+	var order []string
+
+	trace := func(name string) Interceptor {
+		return func(call *Call, next func() error) error {
+			order = append(order, name+" before "+call.Op)
+			err := next()
+			order = append(order, name+" after "+call.Op)
+
+			return err
+		}
+	}
+
+	l := interceptorTestLogger(&bufferTestLogger{}, trace("first"), trace("second"))
+	txMock := &transactionMock{}
+	txMock.On("Commit").Return(nil)
+
+	tx := &transaction{Tx: txMock, ctx: context.TODO(), logger: l, id: "tx", connID: "conn"}
+	assert.NoError(t, tx.Commit())
+	assert.Equal(t, []string{
+		"first before Commit",
+		"second before Commit",
+		"second after Commit",
+		"first after Commit",
+	}, order)
+}
+
+func TestInterceptor_Retry(t *testing.T) {
+	var calls int
+
+	l := interceptorTestLogger(&bufferTestLogger{}, func(call *Call, next func() error) error {
+		err := next()
+		for i := 0; i < 2 && errors.Is(err, driver.ErrBadConn); i++ {
+			err = next()
+		}
+
+		calls++
+
+		return err
+	})
+
+	driverConnMock := &driverConnPingerMock{}
+	driverConnMock.On("Ping").Return(driver.ErrBadConn).Twice()
+	driverConnMock.On("Ping").Return(nil).Once()
+
+	conn := &connection{Conn: driverConnMock, logger: l, id: "conn"}
+	assert.NoError(t, conn.Ping(context.TODO()))
+	assert.Equal(t, 1, calls)
+	driverConnMock.AssertNumberOfCalls(t, "Ping", 3)
+}
+
+func TestInterceptor_Call(t *testing.T) {
+	var got Call
+
+	l := interceptorTestLogger(&bufferTestLogger{}, func(call *Call, next func() error) error {
+		err := next()
+		got = *call
+
+		return err
+	})
+
+	resMock := &resultMock{}
+	resMock.On("RowsAffected").Return(2, nil)
+
+	args := []driver.NamedValue{{Ordinal: 1, Value: 1}}
+	res := &result{Result: resMock, ctx: context.TODO(), logger: l, connID: "conn", txID: "tx", stmtID: "stmt", query: "DELETE FROM tt WHERE id = ?", args: args}
+	num, err := res.RowsAffected()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), num)
+	assert.Equal(t, "ResultRowsAffected", got.Op)
+	assert.Equal(t, "DELETE FROM tt WHERE id = ?", got.Query)
+	assert.Equal(t, args, got.Args)
+	assert.Equal(t, "conn", got.ConnID)
+	assert.Equal(t, "tx", got.TxID)
+	assert.Equal(t, "stmt", got.StmtID)
+	assert.False(t, got.Start.IsZero())
+}
+
+func TestInterceptor_NoResult(t *testing.T) {
+	bl := &bufferTestLogger{}
+	l := interceptorTestLogger(bl, func(call *Call, next func() error) error {
+		return nil // never call next
+	})
+
+	conn := &connection{Conn: &driverConnTxMock{}, logger: l, id: "conn"}
+	queryConn := &connection{Conn: &driverConnQueryerContextMock{}, logger: l, id: "conn"}
+
+	tt := []struct {
+		op   string
+		call func() (interface{}, error)
+	}{
+		{op: "QueryContext", call: func() (interface{}, error) { return queryConn.QueryContext(context.TODO(), "SELECT 1", nil) }},
+		{op: "ExecContext", call: func() (interface{}, error) { return conn.ExecContext(context.TODO(), "DELETE FROM tt", nil) }},
+		{op: "PrepareContext", call: func() (interface{}, error) { return conn.PrepareContext(context.TODO(), "SELECT 1") }},
+		{op: "BeginTx", call: func() (interface{}, error) { return conn.BeginTx(context.TODO(), driver.TxOptions{}) }},
+		{op: "Connect", call: func() (interface{}, error) {
+			return (&connector{driver: &driverMock{}, logger: l}).Connect(context.TODO())
+		}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.op, func(t *testing.T) {
+			res, err := tc.call()
+			assert.ErrorIs(t, err, ErrInterceptorNoResult)
+			assert.Nil(t, res)
+
+			var output bufLog
+			assert.NoError(t, json.Unmarshal(bl.Bytes(), &output))
+			assert.Equal(t, tc.op, output.Message)
+			assert.Equal(t, LevelError.String(), output.Level)
+			assert.Equal(t, ErrInterceptorNoResult.Error(), output.Data[l.opt.errorFieldname])
+		})
+	}
+}
+
+func TestInterceptor_ReplaceContext(t *testing.T) {
+	type ctxKey struct{}
+
+	l := interceptorTestLogger(&bufferTestLogger{}, func(call *Call, next func() error) error {
+		call.Ctx = context.WithValue(call.Ctx, ctxKey{}, call.Op)
+
+		return next()
+	})
+	ctx := context.TODO()
+
+	driverConnMock := &driverConnTxMock{}
+	driverConnMock.On("BeginTx", mock.Anything, mock.Anything).Return(&transactionMock{}, nil)
+	driverConnMock.On("PrepareContext", mock.Anything).Return(&statementMock{}, nil)
+
+	conn := &connection{Conn: driverConnMock, logger: l, id: "conn"}
+	tx, err := conn.BeginTx(ctx, driver.TxOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "BeginTx", driverConnMock.Calls[0].Arguments.Get(0).(context.Context).Value(ctxKey{}))
+	assert.Equal(t, ctx, tx.(*transaction).ctx)
+
+	stmt, err := conn.PrepareContext(ctx, "SELECT 1")
+	assert.NoError(t, err)
+	assert.Equal(t, ctx, stmt.(*statement).ctx)
+
+	queryConnMock := &driverConnQueryerContextMock{}
+	queryConnMock.On("QueryContext", mock.Anything, mock.Anything, mock.Anything).Return(&rowsMock{}, nil)
+
+	queryConn := &connection{Conn: queryConnMock, logger: l, id: "conn"}
+	rs, err := queryConn.QueryContext(ctx, "SELECT 1", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, ctx, rs.(*rows).ctx)
+}
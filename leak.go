@@ -0,0 +1,102 @@
+package sqldblogger
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"runtime"
+	"runtime/debug"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// ErrLeakGarbageCollected is logged when rows garbage collected without Close().
+	ErrLeakGarbageCollected = errors.New("sqldblogger: garbage collected without close")
+	// ErrLeakLifetimeExceeded is logged when rows, statement or transaction still not closed
+	// after leak detection max lifetime.
+	ErrLeakLifetimeExceeded = errors.New("sqldblogger: not closed after max lifetime")
+)
+
+// leakStackFieldname is fieldname of stack trace captured when leaked object created.
+const leakStackFieldname = "stack"
+
+// leakTracker report leaked rows, statement or transaction (see: WithLeakDetection).
+//
+// It must not reference the tracked object, otherwise the object never garbage collected.
+type leakTracker struct {
+	logger *logger
+	op     string
+	start  time.Time
+	stack  string
+	// connID, txID, stmtID, query and args of tracked object, they are logged on leak report.
+	connID string
+	txID   string
+	stmtID string
+	query  string
+	args   []driver.NamedValue
+	timer  *time.Timer
+	// done is 1 when tracked object closed or leak reported.
+	done int32
+}
+
+// leakObject is rows, statement or transaction tracked by leak detector.
+type leakObject interface {
+	// leakData set leak report log data to given tracker, it must not reference the object.
+	leakData(t *leakTracker)
+}
+
+// trackLeak start tracking given object until returned tracker closed, nil if leak detection disabled.
+//
+// Garbage collected leak is only tracked when collectable is true. Transaction and statement are never
+// garbage collected while its connection is alive, because database/sql keep the connection (which reference its
+// transaction and prepared statements) in the pool, so they are only tracked by max lifetime.
+func (l *logger) trackLeak(obj leakObject, op string, collectable bool) *leakTracker {
+	opt := l.options()
+	if !opt.leakDetection || (!collectable && opt.leakMaxLifetime <= 0) {
+		return nil
+	}
+
+	t := &leakTracker{logger: l, op: op, start: time.Now(), stack: string(debug.Stack())}
+	obj.leakData(t)
+
+	if collectable {
+		runtime.SetFinalizer(obj, func(interface{}) { t.report(ErrLeakGarbageCollected) })
+	}
+
+	if opt.leakMaxLifetime > 0 {
+		t.timer = time.AfterFunc(opt.leakMaxLifetime, func() { t.report(ErrLeakLifetimeExceeded) })
+	}
+
+	return t
+}
+
+// close mark tracked object as closed.
+// It is safe to call on nil tracker (disabled).
+func (t *leakTracker) close() {
+	if t == nil || !atomic.CompareAndSwapInt32(&t.done, 0, 1) {
+		return
+	}
+
+	if t.timer != nil {
+		t.timer.Stop()
+	}
+}
+
+// report log leaked object once, with its stack trace when created.
+func (t *leakTracker) report(err error) {
+	if !atomic.CompareAndSwapInt32(&t.done, 0, 1) {
+		return
+	}
+
+	// leak report is not a driver call, the call only carry log data.
+	call := t.logger.newCall(context.Background(), t.op, nil)
+	defer call.release()
+
+	call.Start, call.Err = t.start, err
+	call.ConnID, call.TxID, call.StmtID = t.connID, t.txID, t.stmtID
+	call.Query, call.Args = t.query, t.args
+	call.SetField(leakStackFieldname, t.stack)
+
+	t.logger.logCall(call, LevelError, nil)
+}
@@ -0,0 +1,94 @@
+package sqldblogger
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeakDetection_GarbageCollected(t *testing.T) {
+	bl := &syncTestLogger{}
+	l := newLogger(bl, WithLeakDetection(true, 0))
+	conn := &connection{logger: l, id: "conn"}
+
+	_, err := conn.rows(context.TODO(), &rowsMock{}, nil, "SELECT 1", nil)
+	assert.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		runtime.GC()
+		return len(bl.get()) > 0
+	}, time.Second, 10*time.Millisecond)
+
+	logs := bl.get()
+	assert.Equal(t, "RowsLeak", logs[0].msg)
+	assert.Equal(t, LevelError, logs[0].level)
+	assert.Equal(t, ErrLeakGarbageCollected.Error(), logs[0].data[l.opt.errorFieldname])
+	assert.Equal(t, "conn", logs[0].data[l.opt.connIDFieldname])
+	assert.Equal(t, "SELECT 1", logs[0].data[l.opt.sqlQueryFieldname])
+	assert.Contains(t, logs[0].data[leakStackFieldname], "TestLeakDetection_GarbageCollected")
+}
+
+func TestLeakDetection_MaxLifetime(t *testing.T) {
+	bl := &syncTestLogger{}
+	l := newLogger(bl, WithLeakDetection(true, 10*time.Millisecond))
+	conn := &connection{logger: l, id: "conn"}
+
+	stmtMock := &statementMock{}
+	stmtMock.On("Close").Return(nil)
+
+	stmt, err := conn.statement(context.TODO(), stmtMock, nil, "stmt", "SELECT 1")
+	assert.NoError(t, err)
+
+	assert.Eventually(t, func() bool { return len(bl.get()) > 0 }, time.Second, 5*time.Millisecond)
+
+	logs := bl.get()
+	assert.Equal(t, "StmtLeak", logs[0].msg)
+	assert.Equal(t, ErrLeakLifetimeExceeded.Error(), logs[0].data[l.opt.errorFieldname])
+	assert.Equal(t, "stmt", logs[0].data[l.opt.stmtIDFieldname])
+	assert.Contains(t, logs[0].data, leakStackFieldname)
+
+	// reported leak is not reported again on close
+	assert.NoError(t, stmt.Close())
+
+	for _, lg := range bl.get()[1:] {
+		assert.NotEqual(t, "StmtLeak", lg.msg)
+	}
+}
+
+func TestLeakDetection_Closed(t *testing.T) {
+	bl := &syncTestLogger{}
+	l := newLogger(bl, WithLeakDetection(true, 10*time.Millisecond))
+	conn := &connection{logger: l, id: "conn"}
+
+	txMock := &transactionMock{}
+	txMock.On("Commit").Return(nil)
+
+	tx, err := conn.transaction(context.TODO(), txMock, nil, "tx")
+	assert.NoError(t, err)
+	assert.NoError(t, tx.Commit())
+	time.Sleep(30 * time.Millisecond)
+	runtime.GC()
+
+	for _, lg := range bl.get() {
+		assert.NotEqual(t, "TxLeak", lg.msg)
+	}
+}
+
+func TestLeakDetection_Disabled(t *testing.T) {
+	l := newLogger(&syncTestLogger{})
+	assert.Nil(t, l.trackLeak(&rows{}, "RowsLeak", true))
+
+	// transaction and statement are only tracked by max lifetime
+	l = newLogger(&syncTestLogger{}, WithLeakDetection(true, 0))
+	assert.Nil(t, l.trackLeak(&transaction{}, "TxLeak", false))
+	assert.Nil(t, l.trackLeak(&statement{}, "StmtLeak", false))
+	tracker := l.trackLeak(&rows{logger: l}, "RowsLeak", true)
+	assert.NotNil(t, tracker)
+	tracker.close()
+
+	tracker = nil
+	tracker.close()
+}
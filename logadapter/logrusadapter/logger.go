@@ -22,18 +22,27 @@ func New(logger *logrus.Logger) sqldblogger.Logger {
 func (l *logrusAdapter) Log(ctx context.Context, level sqldblogger.Level, msg string, data map[string]interface{}) {
 	// logrus will rename "time" field to "fields.time" and provide their own time value (RFC3339)
 	// see: https://github.com/sirupsen/logrus#entries
-	entry := l.logger.WithContext(ctx).WithFields(data)
+	l.logger.WithContext(ctx).WithFields(data).Log(logrusLevel(level), msg)
+}
+
+// Enabled implement sqldblogger.LevelEnabler using logrus logger level.
+func (l *logrusAdapter) Enabled(_ context.Context, level sqldblogger.Level) bool {
+	return l.logger.IsLevelEnabled(logrusLevel(level))
+}
 
+func logrusLevel(level sqldblogger.Level) logrus.Level {
 	switch level {
 	case sqldblogger.LevelError:
-		entry.Error(msg)
+		return logrus.ErrorLevel
+	case sqldblogger.LevelWarn:
+		return logrus.WarnLevel
 	case sqldblogger.LevelInfo:
-		entry.Info(msg)
+		return logrus.InfoLevel
 	case sqldblogger.LevelDebug:
-		entry.Debug(msg)
+		return logrus.DebugLevel
 	case sqldblogger.LevelTrace:
-		entry.Trace(msg)
+		return logrus.TraceLevel
 	default:
-		entry.Debug(msg)
+		return logrus.DebugLevel
 	}
 }
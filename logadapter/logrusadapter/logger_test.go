@@ -34,6 +34,7 @@ func TestLogrusAdapter_Log(t *testing.T) {
 
 	lvls := map[sqldblogger.Level]string{
 		sqldblogger.LevelError: "error",
+		sqldblogger.LevelWarn:  "warning",
 		sqldblogger.LevelInfo:  "info",
 		sqldblogger.LevelDebug: "debug",
 		sqldblogger.LevelTrace: "trace",
@@ -68,3 +69,15 @@ func TestLogrusAdapter_Log(t *testing.T) {
 		wr.Reset()
 	}
 }
+
+func TestLogrusAdapter_Enabled(t *testing.T) {
+	lr := logrus.New()
+	lr.Level = logrus.WarnLevel
+	le, ok := New(lr).(sqldblogger.LevelEnabler)
+	assert.True(t, ok)
+
+	assert.True(t, le.Enabled(context.TODO(), sqldblogger.LevelError))
+	assert.True(t, le.Enabled(context.TODO(), sqldblogger.LevelWarn))
+	assert.False(t, le.Enabled(context.TODO(), sqldblogger.LevelInfo))
+	assert.False(t, le.Enabled(context.TODO(), sqldblogger.LevelTrace))
+}
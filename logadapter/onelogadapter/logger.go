@@ -10,11 +10,24 @@ import (
 
 type onelogAdapter struct {
 	logger *onelog.Logger
+	levels uint8
 }
 
 // New set onelog logger as backend as an example on how it process log from sqldblogger.Log().
-func New(logger *onelog.Logger) sqldblogger.Logger {
-	return &onelogAdapter{logger: logger}
+// Optional levels is the same levels given to onelog.New() (e.g: onelog.WARN|onelog.ERROR),
+// which is used by Enabled() because onelog does not expose its levels. Every level is enabled if not given.
+func New(logger *onelog.Logger, levels ...uint8) sqldblogger.Logger {
+	oa := &onelogAdapter{logger: logger, levels: onelog.ALL}
+
+	if len(levels) > 0 {
+		oa.levels = 0
+
+		for _, l := range levels {
+			oa.levels |= l
+		}
+	}
+
+	return oa
 }
 
 // Log implement sqldblogger.Logger and log it as is.
@@ -22,15 +35,14 @@ func New(logger *onelog.Logger) sqldblogger.Logger {
 func (oa *onelogAdapter) Log(_ context.Context, level sqldblogger.Level, msg string, data map[string]interface{}) {
 	var chain onelog.ChainEntry
 
-	switch level {
-	case sqldblogger.LevelError:
+	switch onelogLevel(level) {
+	case onelog.ERROR:
 		chain = oa.logger.ErrorWith(msg)
-	case sqldblogger.LevelInfo:
+	case onelog.WARN:
+		chain = oa.logger.WarnWith(msg)
+	case onelog.INFO:
 		chain = oa.logger.InfoWith(msg)
-	case sqldblogger.LevelDebug:
-		chain = oa.logger.DebugWith(msg)
 	default:
-		// trace will use onelog debug
 		chain = oa.logger.DebugWith(msg)
 	}
 
@@ -40,3 +52,22 @@ func (oa *onelogAdapter) Log(_ context.Context, level sqldblogger.Level, msg str
 
 	chain.Write()
 }
+
+// Enabled implement sqldblogger.LevelEnabler using onelog levels given to New().
+func (oa *onelogAdapter) Enabled(_ context.Context, level sqldblogger.Level) bool {
+	return onelogLevel(level)&oa.levels != 0
+}
+
+func onelogLevel(level sqldblogger.Level) uint8 {
+	switch level {
+	case sqldblogger.LevelError:
+		return onelog.ERROR
+	case sqldblogger.LevelWarn:
+		return onelog.WARN
+	case sqldblogger.LevelInfo:
+		return onelog.INFO
+	default:
+		// trace will use onelog debug
+		return onelog.DEBUG
+	}
+}
@@ -30,6 +30,7 @@ func TestOnelogAdapter_Log(t *testing.T) {
 
 	lvls := map[sqldblogger.Level]string{
 		sqldblogger.LevelError: "error",
+		sqldblogger.LevelWarn:  "warn",
 		sqldblogger.LevelInfo:  "info",
 		sqldblogger.LevelDebug: "debug",
 		sqldblogger.LevelTrace: "debug",
@@ -64,3 +65,16 @@ func TestOnelogAdapter_Log(t *testing.T) {
 		wr.Reset()
 	}
 }
+
+func TestOnelogAdapter_Enabled(t *testing.T) {
+	le, ok := New(onelog.New(&bytes.Buffer{}, onelog.WARN|onelog.ERROR), onelog.WARN|onelog.ERROR).(sqldblogger.LevelEnabler)
+	assert.True(t, ok)
+
+	assert.True(t, le.Enabled(context.TODO(), sqldblogger.LevelError))
+	assert.True(t, le.Enabled(context.TODO(), sqldblogger.LevelWarn))
+	assert.False(t, le.Enabled(context.TODO(), sqldblogger.LevelInfo))
+	assert.False(t, le.Enabled(context.TODO(), sqldblogger.LevelTrace))
+
+	le = New(onelog.New(&bytes.Buffer{}, onelog.ALL)).(sqldblogger.LevelEnabler)
+	assert.True(t, le.Enabled(context.TODO(), sqldblogger.LevelTrace))
+}
@@ -0,0 +1,38 @@
+package oteladapter
+
+import (
+	"time"
+
+	sqldblogger "github.com/simukti/sqldb-logger"
+)
+
+// NewInterceptor create span for every traced operation call (see: sqldblogger.WithInterceptors).
+//
+// Unlike New, span is created for every call regardless of log level, rules and sampling,
+// and it covers the actual call duration. Span context is set to the call context, so it reach
+// the driver and the log context of the call, while transaction, statement and rows created by the call keep
+// the original context (e.g: Commit span is parented on the BeginTx parent, not on the BeginTx span).
+// Put it first in interceptors chain to trace the whole chain.
+func NewInterceptor(opts ...Option) sqldblogger.Interceptor {
+	oa := newAdapter(nil, opts)
+
+	return func(call *sqldblogger.Call, next func() error) error {
+		if !oa.opt.operations[call.Op] {
+			return next()
+		}
+
+		ctx, span := oa.start(call.Ctx, spanData{
+			op:     call.Op,
+			query:  call.Query,
+			connID: call.ConnID,
+			stmtID: call.StmtID,
+			txID:   call.TxID,
+		})
+		call.Ctx = ctx
+
+		err := next()
+		end(span, err, time.Time{})
+
+		return err
+	}
+}
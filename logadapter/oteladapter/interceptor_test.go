@@ -0,0 +1,133 @@
+package oteladapter
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	sqldblogger "github.com/simukti/sqldb-logger"
+)
+
+func TestInterceptor(t *testing.T) {
+	rec, tp := newRecorder()
+	intercept := NewInterceptor(WithTracerProvider(tp), WithDBSystem(sqldblogger.SQLDialectMySQL.String()))
+
+	parentCtx, parent := tp.Tracer("test").Start(context.TODO(), "parent")
+	defer parent.End()
+
+	call := &sqldblogger.Call{Ctx: parentCtx, Op: "ExecContext", Query: "DELETE FROM tt WHERE id = ?", ConnID: "conn", StmtID: "stmt"}
+	err := intercept(call, func() error {
+		// span context reach the driver call
+		assert.Equal(t, parent.SpanContext().TraceID(), trace.SpanContextFromContext(call.Ctx).TraceID())
+		assert.NotEqual(t, parent.SpanContext().SpanID(), trace.SpanContextFromContext(call.Ctx).SpanID())
+		time.Sleep(10 * time.Millisecond)
+
+		return nil
+	})
+	assert.NoError(t, err)
+
+	spans := rec.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "ExecContext", spans[0].Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.GreaterOrEqual(t, spans[0].EndTime().Sub(spans[0].StartTime()), 10*time.Millisecond)
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+
+	a := attrs(spans[0].Attributes())
+	assert.Equal(t, "mysql", a["db.system"])
+	assert.Equal(t, "DELETE", a["db.operation"])
+	assert.Equal(t, "DELETE FROM tt WHERE id = ?", a["db.statement"])
+	assert.Equal(t, "conn", a["db.sqldblogger.conn_id"])
+	assert.Equal(t, "stmt", a["db.sqldblogger.stmt_id"])
+}
+
+func TestInterceptor_Error(t *testing.T) {
+	rec, tp := newRecorder()
+	intercept := NewInterceptor(WithTracerProvider(tp))
+	errDriver := errors.New("driver error")
+
+	err := intercept(&sqldblogger.Call{Ctx: context.TODO(), Op: "Commit"}, func() error { return errDriver })
+	assert.ErrorIs(t, err, errDriver)
+
+	spans := rec.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "driver error", spans[0].Status().Description)
+	assert.Equal(t, "COMMIT", attrs(spans[0].Attributes())["db.operation"])
+}
+
+func TestInterceptor_NotTraced(t *testing.T) {
+	rec, tp := newRecorder()
+	intercept := NewInterceptor(WithTracerProvider(tp))
+
+	var called bool
+
+	ctx := context.TODO()
+	call := &sqldblogger.Call{Ctx: ctx, Op: "Ping"}
+	assert.NoError(t, intercept(call, func() error { called = true; return nil }))
+	assert.True(t, called)
+	assert.Equal(t, ctx, call.Ctx)
+	assert.Empty(t, rec.Ended())
+}
+
+func TestInterceptor_MaskSQLLiterals(t *testing.T) {
+	rec, tp := newRecorder()
+	intercept := NewInterceptor(WithTracerProvider(tp), WithMaskSQLLiterals(sqldblogger.SQLDialectGeneric))
+
+	call := &sqldblogger.Call{Ctx: context.TODO(), Op: "QueryContext", Query: "SELECT * FROM users WHERE email = 'x@y.com' AND id = 1"}
+	assert.NoError(t, intercept(call, func() error { return nil }))
+
+	spans := rec.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "SELECT * FROM users WHERE email = ? AND id = ?", attrs(spans[0].Attributes())["db.statement"])
+	assert.Equal(t, "SELECT", attrs(spans[0].Attributes())["db.operation"])
+	// query passed to next is not masked
+	assert.Equal(t, "SELECT * FROM users WHERE email = 'x@y.com' AND id = 1", call.Query)
+}
+
+func TestInterceptor_TransactionParent(t *testing.T) {
+	rec, tp := newRecorder()
+	db := sqldblogger.OpenDriver("dsn", txDriver{}, &nextLogger{},
+		sqldblogger.WithInterceptors(NewInterceptor(WithTracerProvider(tp))))
+	defer db.Close()
+
+	ctx, parent := tp.Tracer("test").Start(context.TODO(), "parent")
+	defer parent.End()
+
+	tx, err := db.BeginTx(ctx, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, tx.Commit())
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, s := range rec.Ended() {
+		spans[s.Name()] = s
+	}
+
+	assert.Contains(t, spans, "BeginTx")
+	assert.Contains(t, spans, "Commit")
+	assert.Equal(t, parent.SpanContext().SpanID(), spans["BeginTx"].Parent().SpanID())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans["Commit"].Parent().SpanID())
+}
+
+// txDriver is driver which only support transaction.
+type txDriver struct{}
+
+func (txDriver) Open(_ string) (driver.Conn, error) { return txConn{}, nil }
+
+type txConn struct{}
+
+func (txConn) Prepare(_ string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (txConn) Close() error                          { return nil }
+func (txConn) Begin() (driver.Tx, error)             { return txConn{}, nil }
+func (txConn) Commit() error                         { return nil }
+func (txConn) Rollback() error                       { return nil }
+func (txConn) BeginTx(_ context.Context, _ driver.TxOptions) (driver.Tx, error) {
+	return txConn{}, nil
+}
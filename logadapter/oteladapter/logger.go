@@ -0,0 +1,303 @@
+package oteladapter
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	sqldblogger "github.com/simukti/sqldb-logger"
+)
+
+// instrumentationName is default tracer name.
+const instrumentationName = "github.com/simukti/sqldb-logger/logadapter/oteladapter"
+
+// Fieldnames is sqldblogger log data fieldnames used to build span,
+// it must match sqldblogger With*Fieldname options if changed.
+type Fieldnames struct {
+	Time   string
+	Start  string
+	Query  string
+	Error  string
+	ConnID string
+	StmtID string
+	TxID   string
+}
+
+// DefaultFieldnames return sqldblogger default fieldnames.
+func DefaultFieldnames() Fieldnames {
+	return Fieldnames{
+		Time:   "time",
+		Start:  "start",
+		Query:  "query",
+		Error:  "error",
+		ConnID: "conn_id",
+		StmtID: "stmt_id",
+		TxID:   "tx_id",
+	}
+}
+
+type options struct {
+	tracerProvider trace.TracerProvider
+	dbSystem       string
+	operations     map[string]bool
+	fieldnames     Fieldnames
+	maskLiterals   bool
+	sqlDialect     sqldblogger.SQLDialect
+}
+
+// Option is adapter option.
+type Option func(opt *options)
+
+// WithTracerProvider set tracer provider to create spans.
+//
+// Default: otel.GetTracerProvider()
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(opt *options) {
+		opt.tracerProvider = tp
+	}
+}
+
+// WithDBSystem set db.system attribute value (e.g: sqldblogger.SQLDialectPostgres.String()).
+//
+// Default: other_sql
+func WithDBSystem(system string) Option {
+	return func(opt *options) {
+		opt.dbSystem = system
+	}
+}
+
+// WithOperations set log messages (operation names) which create a span.
+//
+// Default: ExecContext, QueryContext, PrepareContext, BeginTx, Commit, Rollback, StmtExecContext, StmtQueryContext
+func WithOperations(ops ...string) Option {
+	return func(opt *options) {
+		opt.operations = make(map[string]bool, len(ops))
+		for _, op := range ops {
+			opt.operations[op] = true
+		}
+	}
+}
+
+// WithMaskSQLLiterals replace literal values in db.statement attribute with "?" (see: sqldblogger.MaskSQLLiterals),
+// using given dialect quoting rules. Use it with NewInterceptor, which trace the query before sqldblogger masks it.
+//
+// Default: disabled (db.statement is the query as is)
+func WithMaskSQLLiterals(dialect sqldblogger.SQLDialect) Option {
+	return func(opt *options) {
+		opt.maskLiterals, opt.sqlDialect = true, dialect
+	}
+}
+
+// WithFieldnames set sqldblogger log data fieldnames, use it when sqldblogger fieldnames changed.
+//
+// Default: DefaultFieldnames()
+func WithFieldnames(f Fieldnames) Option {
+	return func(opt *options) {
+		opt.fieldnames = f
+	}
+}
+
+type otelAdapter struct {
+	next   sqldblogger.Logger
+	tracer trace.Tracer
+	opt    *options
+}
+
+// New create span for every traced operation log, then pass the log to next logger (if not nil).
+//
+// Span is parented on the span from given log context, its start and end time are the call start time
+// and log time of sqldblogger.Event (see: LogEvent).
+//
+// When it is used as plain sqldblogger.Logger (see: Log), to reuse the same start and end time
+// computed by sqldblogger, set sqldblogger.WithIncludeStartTime(true) and
+// sqldblogger.WithTimeFormat(sqldblogger.TimeFormatUnixNano) (or TimeFormatRFC3339Nano),
+// otherwise span is created when the log delivered with zero duration.
+//
+// Span is only created for log which is actually delivered, so log dropped by minimum level, level rules,
+// sampling or Logger level check has no span. Use NewInterceptor to trace every call.
+func New(next sqldblogger.Logger, opts ...Option) sqldblogger.Logger {
+	return newAdapter(next, opts)
+}
+
+func newAdapter(next sqldblogger.Logger, opts []Option) *otelAdapter {
+	opt := &options{
+		tracerProvider: otel.GetTracerProvider(),
+		dbSystem:       sqldblogger.SQLDialectGeneric.String(),
+		fieldnames:     DefaultFieldnames(),
+	}
+
+	WithOperations(
+		"ExecContext", "QueryContext", "PrepareContext", "BeginTx", "Commit", "Rollback",
+		"StmtExecContext", "StmtQueryContext",
+	)(opt)
+
+	for _, o := range opts {
+		o(opt)
+	}
+
+	return &otelAdapter{next: next, tracer: opt.tracerProvider.Tracer(instrumentationName), opt: opt}
+}
+
+// Log implement sqldblogger.Logger, create a span for traced operation and pass the log to next logger.
+func (oa *otelAdapter) Log(ctx context.Context, level sqldblogger.Level, msg string, data map[string]interface{}) {
+	if oa.opt.operations[msg] {
+		oa.span(ctx, oa.spanFromData(msg, data))
+	}
+
+	if oa.next != nil {
+		oa.next.Log(ctx, level, msg, data)
+	}
+}
+
+// LogEvent implement sqldblogger.EventLogger, create a span for traced operation from typed event,
+// so fieldnames and time format options are not needed. The event is passed to next logger,
+// as log data map if next logger does not implement sqldblogger.EventLogger.
+func (oa *otelAdapter) LogEvent(ctx context.Context, e *sqldblogger.Event) {
+	if oa.opt.operations[e.Op] {
+		var err error
+		if e.Level >= sqldblogger.LevelWarn {
+			err = e.Err
+		}
+
+		oa.span(ctx, spanData{
+			op:     e.Op,
+			query:  e.Query,
+			connID: e.ConnID,
+			stmtID: e.StmtID,
+			txID:   e.TxID,
+			start:  e.Start,
+			end:    e.Time,
+			err:    err,
+		})
+	}
+
+	switch next := oa.next.(type) {
+	case nil:
+	case sqldblogger.EventLogger:
+		next.LogEvent(ctx, e)
+	default:
+		next.Log(ctx, e.Level, e.Message, e.Data())
+	}
+}
+
+// spanData is span information from log data or typed event, zero start or end time means current time.
+type spanData struct {
+	op, query            string
+	connID, stmtID, txID string
+	start, end           time.Time
+	err                  error
+}
+
+// spanFromData build span information from sqldblogger log data.
+func (oa *otelAdapter) spanFromData(op string, data map[string]interface{}) spanData {
+	f := oa.opt.fieldnames
+	sd := spanData{op: op}
+	sd.query, _ = data[f.Query].(string)
+	sd.connID, _ = data[f.ConnID].(string)
+	sd.stmtID, _ = data[f.StmtID].(string)
+	sd.txID, _ = data[f.TxID].(string)
+	sd.start, _ = parseTime(data[f.Start])
+	sd.end, _ = parseTime(data[f.Time])
+
+	if errMsg, ok := data[f.Error].(string); ok && errMsg != "" {
+		sd.err = errors.New(errMsg)
+	}
+
+	return sd
+}
+
+func (oa *otelAdapter) span(ctx context.Context, sd spanData) {
+	_, span := oa.start(ctx, sd)
+	end(span, sd.err, sd.end)
+}
+
+// start create span of given span information, parented on the span from given context.
+func (oa *otelAdapter) start(ctx context.Context, sd spanData) (context.Context, trace.Span) {
+	if oa.opt.maskLiterals {
+		sd.query = sqldblogger.MaskSQLLiterals(sd.query, oa.opt.sqlDialect)
+	}
+
+	attrs := []attribute.KeyValue{
+		attribute.String("db.system", oa.opt.dbSystem),
+		attribute.String("db.operation", operation(sd.op, sd.query)),
+	}
+
+	if sd.query != "" {
+		attrs = append(attrs, attribute.String("db.statement", sd.query))
+	}
+
+	for _, id := range []struct{ name, value string }{
+		{"db.sqldblogger.conn_id", sd.connID},
+		{"db.sqldblogger.stmt_id", sd.stmtID},
+		{"db.sqldblogger.tx_id", sd.txID},
+	} {
+		if id.value != "" {
+			attrs = append(attrs, attribute.String(id.name, id.value))
+		}
+	}
+
+	startOpts := []trace.SpanStartOption{trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...)}
+	if !sd.start.IsZero() {
+		startOpts = append(startOpts, trace.WithTimestamp(sd.start))
+	}
+
+	return oa.tracer.Start(ctx, sd.op, startOpts...)
+}
+
+// end record given error (if any) to the span and end it, zero end time means current time.
+func end(span trace.Span, err error, endTime time.Time) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	var endOpts []trace.SpanEndOption
+	if !endTime.IsZero() {
+		endOpts = append(endOpts, trace.WithTimestamp(endTime))
+	}
+
+	span.End(endOpts...)
+}
+
+// operation return db.operation value, SQL command from query (e.g: SELECT) or transaction command.
+func operation(op, query string) string {
+	switch op {
+	case "BeginTx":
+		return "BEGIN"
+	case "Commit":
+		return "COMMIT"
+	case "Rollback":
+		return "ROLLBACK"
+	}
+
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return op
+	}
+
+	return strings.ToUpper(fields[0])
+}
+
+// parseTime parse sqldblogger nano precision time format (TimeFormatUnixNano, TimeFormatRFC3339Nano).
+func parseTime(v interface{}) (time.Time, bool) {
+	switch t := v.(type) {
+	case int64:
+		// TimeFormatUnix has no sub-second precision, it is not usable for span time.
+		if t < 1e12 {
+			return time.Time{}, false
+		}
+
+		return time.Unix(0, t), true
+	case string:
+		tm, err := time.Parse(time.RFC3339Nano, t)
+		return tm, err == nil
+	default:
+		return time.Time{}, false
+	}
+}
@@ -0,0 +1,191 @@
+package oteladapter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	sqldblogger "github.com/simukti/sqldb-logger"
+)
+
+type nextLogger struct {
+	msgs  []string
+	datas []map[string]interface{}
+}
+
+func (n *nextLogger) Log(_ context.Context, _ sqldblogger.Level, msg string, data map[string]interface{}) {
+	n.msgs = append(n.msgs, msg)
+	n.datas = append(n.datas, data)
+}
+
+func newRecorder() (*tracetest.SpanRecorder, trace.TracerProvider) {
+	rec := tracetest.NewSpanRecorder()
+	return rec, sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec))
+}
+
+func attrs(kvs []attribute.KeyValue) map[attribute.Key]string {
+	m := make(map[attribute.Key]string, len(kvs))
+	for _, kv := range kvs {
+		m[kv.Key] = kv.Value.AsString()
+	}
+
+	return m
+}
+
+func TestOtelAdapter_Log(t *testing.T) {
+	rec, tp := newRecorder()
+	next := &nextLogger{}
+	logger := New(next, WithTracerProvider(tp), WithDBSystem(sqldblogger.SQLDialectPostgres.String()))
+
+	start := time.Now().Add(-time.Second)
+	end := start.Add(20 * time.Millisecond)
+	logger.Log(context.TODO(), sqldblogger.LevelInfo, "QueryContext", map[string]interface{}{
+		"time":    end.UnixNano(),
+		"start":   start.UnixNano(),
+		"query":   " select * from a_table where id = $1",
+		"conn_id": "conn",
+		"tx_id":   "tx",
+	})
+
+	spans := rec.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "QueryContext", spans[0].Name())
+	assert.Equal(t, trace.SpanKindClient, spans[0].SpanKind())
+	assert.Equal(t, start.UnixNano(), spans[0].StartTime().UnixNano())
+	assert.Equal(t, end.UnixNano(), spans[0].EndTime().UnixNano())
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+
+	a := attrs(spans[0].Attributes())
+	assert.Equal(t, "postgresql", a["db.system"])
+	assert.Equal(t, "SELECT", a["db.operation"])
+	assert.Equal(t, " select * from a_table where id = $1", a["db.statement"])
+	assert.Equal(t, "conn", a["db.sqldblogger.conn_id"])
+	assert.Equal(t, "tx", a["db.sqldblogger.tx_id"])
+	assert.NotContains(t, a, attribute.Key("db.sqldblogger.stmt_id"))
+	assert.Equal(t, []string{"QueryContext"}, next.msgs)
+}
+
+func TestOtelAdapter_LogError(t *testing.T) {
+	rec, tp := newRecorder()
+	logger := New(nil, WithTracerProvider(tp))
+
+	end := time.Now()
+	logger.Log(context.TODO(), sqldblogger.LevelError, "Commit", map[string]interface{}{
+		"time":  end.Format(time.RFC3339Nano),
+		"error": "dummy error",
+	})
+
+	spans := rec.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "dummy error", spans[0].Status().Description)
+	assert.Len(t, spans[0].Events(), 1)
+	assert.Equal(t, end.UnixNano(), spans[0].EndTime().UnixNano())
+	assert.Equal(t, "COMMIT", attrs(spans[0].Attributes())["db.operation"])
+	assert.Equal(t, "other_sql", attrs(spans[0].Attributes())["db.system"])
+}
+
+func TestOtelAdapter_ParentSpan(t *testing.T) {
+	rec, tp := newRecorder()
+	logger := New(nil, WithTracerProvider(tp))
+
+	ctx, parent := tp.Tracer("test").Start(context.TODO(), "parent")
+	logger.Log(ctx, sqldblogger.LevelInfo, "ExecContext", map[string]interface{}{"query": "DELETE FROM a_table"})
+	parent.End()
+
+	spans := rec.Ended()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, parent.SpanContext().TraceID(), spans[0].SpanContext().TraceID())
+}
+
+func TestOtelAdapter_Operations(t *testing.T) {
+	rec, tp := newRecorder()
+	next := &nextLogger{}
+	logger := New(next, WithTracerProvider(tp), WithOperations("Exec"))
+
+	logger.Log(context.TODO(), sqldblogger.LevelDebug, "RowsClose", map[string]interface{}{})
+	logger.Log(context.TODO(), sqldblogger.LevelInfo, "ExecContext", map[string]interface{}{})
+	logger.Log(context.TODO(), sqldblogger.LevelInfo, "Exec", map[string]interface{}{"time": time.Now().Unix()})
+
+	spans := rec.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "Exec", spans[0].Name())
+	assert.Equal(t, "Exec", attrs(spans[0].Attributes())["db.operation"])
+	assert.Equal(t, []string{"RowsClose", "ExecContext", "Exec"}, next.msgs)
+}
+
+func TestOtelAdapter_Fieldnames(t *testing.T) {
+	rec, tp := newRecorder()
+	f := DefaultFieldnames()
+	f.Query = "sql"
+	logger := New(nil, WithTracerProvider(tp), WithFieldnames(f))
+
+	logger.Log(context.TODO(), sqldblogger.LevelInfo, "PrepareContext", map[string]interface{}{"sql": "UPDATE a_table SET a = 1"})
+
+	spans := rec.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "UPDATE", attrs(spans[0].Attributes())["db.operation"])
+	assert.Equal(t, "UPDATE a_table SET a = 1", attrs(spans[0].Attributes())["db.statement"])
+}
+
+type nextEventLogger struct {
+	nextLogger
+	events []*sqldblogger.Event
+}
+
+func (n *nextEventLogger) LogEvent(_ context.Context, e *sqldblogger.Event) {
+	n.events = append(n.events, e)
+}
+
+func TestOtelAdapter_LogEvent(t *testing.T) {
+	rec, tp := newRecorder()
+	next := &nextLogger{}
+	logger := New(next, WithTracerProvider(tp)).(sqldblogger.EventLogger)
+
+	start := time.Now().Add(-time.Second)
+	e := &sqldblogger.Event{
+		Op:      "StmtExecContext",
+		Message: "StmtExecContext",
+		Level:   sqldblogger.LevelError,
+		Start:   start,
+		Time:    start.Add(20 * time.Millisecond),
+		Query:   "DELETE FROM a_table WHERE id = $1",
+		Err:     errors.New("failed"),
+		ConnID:  "conn",
+		StmtID:  "stmt",
+	}
+	logger.LogEvent(context.TODO(), e)
+
+	spans := rec.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "StmtExecContext", spans[0].Name())
+	assert.Equal(t, start.UnixNano(), spans[0].StartTime().UnixNano())
+	assert.Equal(t, e.Time.UnixNano(), spans[0].EndTime().UnixNano())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "failed", spans[0].Status().Description)
+
+	a := attrs(spans[0].Attributes())
+	assert.Equal(t, "DELETE", a["db.operation"])
+	assert.Equal(t, "DELETE FROM a_table WHERE id = $1", a["db.statement"])
+	assert.Equal(t, "conn", a["db.sqldblogger.conn_id"])
+	assert.Equal(t, "stmt", a["db.sqldblogger.stmt_id"])
+
+	// next logger receive log data map converted from the event
+	assert.Equal(t, []string{"StmtExecContext"}, next.msgs)
+	assert.Equal(t, "DELETE FROM a_table WHERE id = $1", next.datas[0]["query"])
+	assert.Equal(t, "failed", next.datas[0]["error"])
+
+	nextEvent := &nextEventLogger{}
+	New(nextEvent, WithTracerProvider(tp)).(sqldblogger.EventLogger).LogEvent(context.TODO(), e)
+	assert.Equal(t, []*sqldblogger.Event{e}, nextEvent.events)
+	assert.Empty(t, nextEvent.msgs)
+}
@@ -0,0 +1,17 @@
+module github.com/simukti/sqldb-logger/logadapter/slogadapter
+
+go 1.21
+
+require (
+	github.com/simukti/sqldb-logger v0.0.0-20230108154142-840120f68bea
+	github.com/stretchr/testify v1.8.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/kr/text v0.2.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/simukti/sqldb-logger => ../../
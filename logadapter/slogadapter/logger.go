@@ -0,0 +1,298 @@
+package slogadapter
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"strconv"
+	"time"
+
+	sqldblogger "github.com/simukti/sqldb-logger"
+)
+
+// LevelTrace is slog level of sqldblogger.LevelTrace, it is below slog.LevelDebug.
+const LevelTrace = slog.LevelDebug - 4
+
+// Fieldnames is log attribute keys, it must match sqldblogger With*Fieldname options if changed
+// and Log() is called with log data map.
+type Fieldnames struct {
+	Time            string
+	Duration        string
+	Query           string
+	Args            string
+	Error           string
+	ConnID          string
+	StmtID          string
+	TxID            string
+	Slow            string
+	Fingerprint     string
+	FingerprintHash string
+	RowsAffected    string
+}
+
+// DefaultFieldnames return sqldblogger default fieldnames.
+func DefaultFieldnames() Fieldnames {
+	return Fieldnames{
+		Time:            "time",
+		Duration:        "duration",
+		Query:           "query",
+		Args:            "args",
+		Error:           "error",
+		ConnID:          "conn_id",
+		StmtID:          "stmt_id",
+		TxID:            "tx_id",
+		Slow:            "slow",
+		Fingerprint:     "query_fingerprint",
+		FingerprintHash: "query_hash",
+		RowsAffected:    "rows_affected",
+	}
+}
+
+type options struct {
+	fieldnames   Fieldnames
+	durationUnit sqldblogger.DurationUnit
+	timeFormat   sqldblogger.TimeFormat
+}
+
+// Option is adapter option.
+type Option func(opt *options)
+
+// WithFieldnames set log attribute keys, use it when sqldblogger fieldnames changed.
+//
+// Default: DefaultFieldnames()
+func WithFieldnames(f Fieldnames) Option {
+	return func(opt *options) {
+		opt.fieldnames = f
+	}
+}
+
+// WithDurationUnit set sqldblogger duration unit (see: sqldblogger.WithDurationUnit),
+// used to convert duration from log data map to slog.DurationValue.
+//
+// Default: sqldblogger.DurationMillisecond
+func WithDurationUnit(du sqldblogger.DurationUnit) Option {
+	return func(opt *options) {
+		opt.durationUnit = du
+	}
+}
+
+// WithTimeFormat set sqldblogger time format (see: sqldblogger.WithTimeFormat),
+// used to parse log time from log data map as the record time.
+//
+// Default: sqldblogger.TimeFormatUnix
+func WithTimeFormat(tf sqldblogger.TimeFormat) Option {
+	return func(opt *options) {
+		opt.timeFormat = tf
+	}
+}
+
+type slogAdapter struct {
+	handler slog.Handler
+	opt     *options
+}
+
+// New set slog logger as backend, the log is passed with its context to the logger handler.
+func New(logger *slog.Logger, opts ...Option) sqldblogger.Logger {
+	opt := &options{fieldnames: DefaultFieldnames(), durationUnit: sqldblogger.DurationMillisecond, timeFormat: sqldblogger.TimeFormatUnix}
+
+	for _, o := range opts {
+		o(opt)
+	}
+
+	return &slogAdapter{handler: logger.Handler(), opt: opt}
+}
+
+// Enabled implement sqldblogger.LevelEnabler using slog handler level.
+func (sa *slogAdapter) Enabled(ctx context.Context, level sqldblogger.Level) bool {
+	return sa.handler.Enabled(ctx, slogLevel(level))
+}
+
+// Log implement sqldblogger.Logger, log data is converted to attributes sorted by key,
+// call duration as slog.DurationValue and arguments as a group. Log time from log data is the record time,
+// so log delivered later (see: sqldblogger.WithAsyncLogger) keep its event time.
+func (sa *slogAdapter) Log(ctx context.Context, level sqldblogger.Level, msg string, data map[string]interface{}) {
+	lvl := slogLevel(level)
+	if !sa.handler.Enabled(ctx, lvl) {
+		return
+	}
+
+	f := sa.opt.fieldnames
+	keys := make([]string, 0, len(data))
+
+	for k := range data {
+		// record has its own time
+		if k != f.Time {
+			keys = append(keys, k)
+		}
+	}
+
+	sort.Strings(keys)
+
+	r := slog.NewRecord(sa.recordTime(data[f.Time]), lvl, msg, 0)
+
+	for _, k := range keys {
+		switch v := data[k].(type) {
+		case float64:
+			if k == f.Duration {
+				r.AddAttrs(slog.Duration(k, sa.duration(v)))
+				continue
+			}
+
+			r.AddAttrs(slog.Float64(k, v))
+		case []interface{}:
+			if k == f.Args {
+				r.AddAttrs(argsAttr(k, v))
+				continue
+			}
+
+			r.AddAttrs(slog.Any(k, v))
+		default:
+			r.AddAttrs(slog.Any(k, v))
+		}
+	}
+
+	_ = sa.handler.Handle(ctx, r)
+}
+
+// LogEvent implement sqldblogger.EventLogger, typed event is converted to typed attributes,
+// followed by other event fields sorted by key.
+func (sa *slogAdapter) LogEvent(ctx context.Context, e *sqldblogger.Event) {
+	lvl := slogLevel(e.Level)
+	if !sa.handler.Enabled(ctx, lvl) {
+		return
+	}
+
+	f := sa.opt.fieldnames
+	r := slog.NewRecord(e.Time, lvl, e.Message, 0)
+
+	if e.ConnID != "" {
+		r.AddAttrs(slog.String(f.ConnID, e.ConnID))
+	}
+
+	if e.TxID != "" {
+		r.AddAttrs(slog.String(f.TxID, e.TxID))
+	}
+
+	if e.StmtID != "" {
+		r.AddAttrs(slog.String(f.StmtID, e.StmtID))
+	}
+
+	if e.Query != "" && e.Query != e.Message {
+		r.AddAttrs(slog.String(f.Query, e.Query))
+	}
+
+	if e.Args != nil {
+		r.AddAttrs(argsAttr(f.Args, e.Args))
+	}
+
+	r.AddAttrs(slog.Duration(f.Duration, e.Duration))
+
+	if e.RowsAffected >= 0 {
+		r.AddAttrs(slog.Int64(f.RowsAffected, e.RowsAffected))
+	}
+
+	if e.Slow {
+		r.AddAttrs(slog.Bool(f.Slow, true))
+	}
+
+	if e.Level >= sqldblogger.LevelWarn && e.Err != nil {
+		r.AddAttrs(slog.Any(f.Error, e.Err))
+	}
+
+	if e.Fingerprint != "" {
+		r.AddAttrs(slog.String(f.Fingerprint, e.Fingerprint), slog.String(f.FingerprintHash, e.FingerprintHash))
+	}
+
+	keys := make([]string, 0, len(e.Fields))
+
+	for k := range e.Fields {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		// time.Duration field (e.g: tx_duration) is slog.DurationValue.
+		r.AddAttrs(slog.Any(k, e.Fields[k]))
+	}
+
+	_ = sa.handler.Handle(ctx, r)
+}
+
+// duration convert duration formatted by sqldblogger duration unit back to time.Duration.
+func (sa *slogAdapter) duration(v float64) time.Duration {
+	switch sa.opt.durationUnit {
+	case sqldblogger.DurationMicrosecond:
+		return time.Duration(v * float64(time.Microsecond))
+	case sqldblogger.DurationMillisecond:
+		return time.Duration(v * float64(time.Millisecond))
+	default:
+		return time.Duration(v)
+	}
+}
+
+// recordTime parse log time formatted by sqldblogger time format, or current time if it is not parsable.
+// Time with second precision (TimeFormatUnix, TimeFormatRFC3339) is replaced by current time within the same second,
+// so synchronously delivered log keep sub-second precision.
+func (sa *slogAdapter) recordTime(v interface{}) time.Time {
+	now := time.Now()
+
+	var (
+		t         time.Time
+		precision = time.Second
+	)
+
+	switch tv := v.(type) {
+	case int64:
+		if sa.opt.timeFormat == sqldblogger.TimeFormatUnixNano {
+			t, precision = time.Unix(0, tv), 0
+		} else {
+			t = time.Unix(tv, 0)
+		}
+	case string:
+		var err error
+		if t, err = time.Parse(time.RFC3339Nano, tv); err != nil {
+			return now
+		}
+
+		if sa.opt.timeFormat == sqldblogger.TimeFormatRFC3339Nano {
+			precision = 0
+		}
+	default:
+		return now
+	}
+
+	if precision > 0 && now.Truncate(precision).Equal(t.Truncate(precision)) {
+		return now
+	}
+
+	return t
+}
+
+// argsAttr create group of query arguments keyed by ordinal position (starting from 1).
+func argsAttr(key string, args []interface{}) slog.Attr {
+	attrs := make([]slog.Attr, len(args))
+
+	for i, a := range args {
+		attrs[i] = slog.Any(strconv.Itoa(i+1), a)
+	}
+
+	return slog.Attr{Key: key, Value: slog.GroupValue(attrs...)}
+}
+
+func slogLevel(level sqldblogger.Level) slog.Level {
+	switch level {
+	case sqldblogger.LevelError:
+		return slog.LevelError
+	case sqldblogger.LevelWarn:
+		return slog.LevelWarn
+	case sqldblogger.LevelInfo:
+		return slog.LevelInfo
+	case sqldblogger.LevelDebug:
+		return slog.LevelDebug
+	case sqldblogger.LevelTrace:
+		return LevelTrace
+	default:
+		return slog.LevelDebug
+	}
+}
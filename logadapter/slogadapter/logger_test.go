@@ -0,0 +1,246 @@
+package slogadapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	sqldblogger "github.com/simukti/sqldb-logger"
+)
+
+func newTestLogger(wr *bytes.Buffer, lvl slog.Level, opts ...Option) sqldblogger.Logger {
+	return New(slog.New(slog.NewJSONHandler(wr, &slog.HandlerOptions{Level: lvl})), opts...)
+}
+
+func TestSlogAdapter_Log(t *testing.T) {
+	wr := &bytes.Buffer{}
+	lg := newTestLogger(wr, LevelTrace)
+
+	lvls := map[sqldblogger.Level]string{
+		sqldblogger.LevelError: "ERROR",
+		sqldblogger.LevelWarn:  "WARN",
+		sqldblogger.LevelInfo:  "INFO",
+		sqldblogger.LevelDebug: "DEBUG",
+		sqldblogger.LevelTrace: "DEBUG-4",
+		sqldblogger.Level(99):  "DEBUG", // unknown
+	}
+
+	for lvl, lvlStr := range lvls {
+		lg.Log(context.TODO(), lvl, "QueryContext", map[string]interface{}{
+			"time":     int64(1),
+			"duration": 1.5,
+			"query":    "SELECT at.* FROM a_table AS at WHERE a.id = ? LIMIT 1",
+			"args":     []interface{}{1, "name"},
+			"conn_id":  "conn",
+		})
+
+		var content map[string]interface{}
+
+		assert.NoError(t, json.Unmarshal(wr.Bytes(), &content))
+		assert.Equal(t, lvlStr, content["level"])
+		assert.Equal(t, "QueryContext", content["msg"])
+		assert.Equal(t, time.Unix(1, 0).Format(time.RFC3339), content["time"])
+		assert.Equal(t, float64(1500*time.Microsecond), content["duration"])
+		assert.Equal(t, "SELECT at.* FROM a_table AS at WHERE a.id = ? LIMIT 1", content["query"])
+		assert.Equal(t, map[string]interface{}{"1": float64(1), "2": "name"}, content["args"])
+		assert.Equal(t, "conn", content["conn_id"])
+
+		wr.Reset()
+	}
+}
+
+func TestSlogAdapter_LogDurationUnit(t *testing.T) {
+	wr := &bytes.Buffer{}
+	lg := newTestLogger(wr, slog.LevelDebug, WithDurationUnit(sqldblogger.DurationMicrosecond),
+		WithFieldnames(Fieldnames{Duration: "elapsed", Args: "params"}))
+
+	lg.Log(context.TODO(), sqldblogger.LevelInfo, "ExecContext", map[string]interface{}{
+		"elapsed": 2.0,
+		"params":  []interface{}{1},
+	})
+
+	var content map[string]interface{}
+
+	assert.NoError(t, json.Unmarshal(wr.Bytes(), &content))
+	assert.Equal(t, float64(2*time.Microsecond), content["elapsed"])
+	assert.Equal(t, map[string]interface{}{"1": float64(1)}, content["params"])
+}
+
+func TestSlogAdapter_LogEventSummaryDurations(t *testing.T) {
+	wr := &bytes.Buffer{}
+	// adapter duration unit is only used by Log(), typed event durations do not depend on it
+	el := newTestLogger(wr, slog.LevelDebug, WithDurationUnit(sqldblogger.DurationMicrosecond)).(sqldblogger.EventLogger)
+
+	el.LogEvent(context.TODO(), &sqldblogger.Event{
+		Op:           "RowsClose",
+		Message:      "RowsClose",
+		Level:        sqldblogger.LevelDebug,
+		RowsAffected: -1,
+		Fields: map[string]interface{}{
+			"rows_fetch_duration": time.Millisecond,
+			"tx_duration":         7 * time.Millisecond,
+			"rows_count":          2,
+		},
+	})
+
+	var content map[string]interface{}
+
+	assert.NoError(t, json.Unmarshal(wr.Bytes(), &content))
+	assert.Equal(t, float64(time.Millisecond), content["rows_fetch_duration"])
+	assert.Equal(t, float64(7*time.Millisecond), content["tx_duration"])
+	assert.Equal(t, float64(2), content["rows_count"])
+}
+
+func TestSlogAdapter_LogTime(t *testing.T) {
+	eventTime := time.Date(2023, 1, 2, 3, 4, 5, 6000, time.UTC)
+
+	tt := []struct {
+		name   string
+		format sqldblogger.TimeFormat
+		value  interface{}
+		expect time.Time
+	}{
+		{name: "unix", format: sqldblogger.TimeFormatUnix, value: eventTime.Unix(), expect: eventTime.Truncate(time.Second)},
+		{name: "unix nano", format: sqldblogger.TimeFormatUnixNano, value: eventTime.UnixNano(), expect: eventTime},
+		{name: "rfc3339", format: sqldblogger.TimeFormatRFC3339, value: eventTime.Format(time.RFC3339), expect: eventTime.Truncate(time.Second)},
+		{name: "rfc3339 nano", format: sqldblogger.TimeFormatRFC3339Nano, value: eventTime.Format(time.RFC3339Nano), expect: eventTime},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			wr := &bytes.Buffer{}
+			lg := newTestLogger(wr, slog.LevelDebug, WithTimeFormat(tc.format))
+			lg.Log(context.TODO(), sqldblogger.LevelInfo, "Ping", map[string]interface{}{"time": tc.value})
+
+			var content map[string]interface{}
+
+			assert.NoError(t, json.Unmarshal(wr.Bytes(), &content))
+			assert.Equal(t, tc.expect.Format(time.RFC3339Nano), content["time"])
+		})
+	}
+
+	// second precision time within current second keep current time precision
+	before := time.Now()
+	rt := newTestLogger(&bytes.Buffer{}, slog.LevelDebug).(*slogAdapter).recordTime(before.Unix())
+	assert.True(t, !rt.Before(before) || time.Now().Unix() != before.Unix()) // unless second changed meanwhile
+
+	// unparsable time is current time
+	rt = newTestLogger(&bytes.Buffer{}, slog.LevelDebug).(*slogAdapter).recordTime("invalid")
+	assert.False(t, rt.Before(before))
+}
+
+func TestSlogAdapter_LogEvent(t *testing.T) {
+	wr := &bytes.Buffer{}
+	lg := newTestLogger(wr, slog.LevelDebug)
+	el, ok := lg.(sqldblogger.EventLogger)
+	assert.True(t, ok)
+
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	el.LogEvent(context.TODO(), &sqldblogger.Event{
+		Op:           "StmtExecContext",
+		Message:      "StmtExecContext",
+		Level:        sqldblogger.LevelError,
+		Time:         now,
+		Duration:     3 * time.Millisecond,
+		Query:        "UPDATE tt SET name = ? WHERE id = ?",
+		Args:         []interface{}{"name", 1},
+		Err:          errors.New("failed"),
+		ConnID:       "conn",
+		StmtID:       "stmt",
+		RowsAffected: 2,
+		Slow:         true,
+		Fields:       map[string]interface{}{"shard": "db-1", "tx_duration": 1500 * time.Microsecond},
+	})
+
+	var content map[string]interface{}
+
+	assert.NoError(t, json.Unmarshal(wr.Bytes(), &content))
+	assert.Equal(t, "ERROR", content["level"])
+	assert.Equal(t, "StmtExecContext", content["msg"])
+	assert.Equal(t, now.Format(time.RFC3339), content["time"])
+	assert.Equal(t, float64(3*time.Millisecond), content["duration"])
+	assert.Equal(t, "UPDATE tt SET name = ? WHERE id = ?", content["query"])
+	assert.Equal(t, map[string]interface{}{"1": "name", "2": float64(1)}, content["args"])
+	assert.Equal(t, "failed", content["error"])
+	assert.Equal(t, "conn", content["conn_id"])
+	assert.Equal(t, "stmt", content["stmt_id"])
+	assert.NotContains(t, content, "tx_id")
+	assert.Equal(t, float64(2), content["rows_affected"])
+	assert.Equal(t, true, content["slow"])
+	assert.Equal(t, "db-1", content["shard"])
+	assert.Equal(t, float64(1500*time.Microsecond), content["tx_duration"])
+}
+
+func TestSlogAdapter_LogEventQueryAsMessage(t *testing.T) {
+	wr := &bytes.Buffer{}
+	el := newTestLogger(wr, slog.LevelDebug).(sqldblogger.EventLogger)
+
+	el.LogEvent(context.TODO(), &sqldblogger.Event{
+		Op:           "Ping",
+		Message:      "SELECT 1",
+		Level:        sqldblogger.LevelInfo,
+		Query:        "SELECT 1",
+		Err:          errors.New("not logged below warn level"),
+		RowsAffected: -1,
+	})
+
+	var content map[string]interface{}
+
+	assert.NoError(t, json.Unmarshal(wr.Bytes(), &content))
+	assert.Equal(t, "SELECT 1", content["msg"])
+	assert.NotContains(t, content, "query")
+	assert.NotContains(t, content, "args")
+	assert.NotContains(t, content, "error")
+	assert.NotContains(t, content, "rows_affected")
+}
+
+func TestSlogAdapter_Enabled(t *testing.T) {
+	wr := &bytes.Buffer{}
+	lg := newTestLogger(wr, slog.LevelWarn)
+	le, ok := lg.(sqldblogger.LevelEnabler)
+	assert.True(t, ok)
+
+	assert.True(t, le.Enabled(context.TODO(), sqldblogger.LevelError))
+	assert.True(t, le.Enabled(context.TODO(), sqldblogger.LevelWarn))
+	assert.False(t, le.Enabled(context.TODO(), sqldblogger.LevelInfo))
+	assert.False(t, le.Enabled(context.TODO(), sqldblogger.LevelTrace))
+
+	lg.Log(context.TODO(), sqldblogger.LevelInfo, "Ping", map[string]interface{}{})
+	assert.Empty(t, wr.Bytes())
+
+	le = newTestLogger(wr, slog.LevelDebug).(sqldblogger.LevelEnabler)
+	assert.False(t, le.Enabled(context.TODO(), sqldblogger.LevelTrace))
+}
+
+type ctxKey struct{}
+
+// ctxHandler record context given to Handle.
+type ctxHandler struct {
+	slog.Handler
+	ctx context.Context
+}
+
+func (h *ctxHandler) Handle(ctx context.Context, r slog.Record) error {
+	h.ctx = ctx
+
+	return h.Handler.Handle(ctx, r)
+}
+
+func TestSlogAdapter_Context(t *testing.T) {
+	h := &ctxHandler{Handler: slog.NewJSONHandler(&bytes.Buffer{}, nil)}
+	lg := New(slog.New(h))
+	ctx := context.WithValue(context.TODO(), ctxKey{}, "req-1")
+
+	lg.Log(ctx, sqldblogger.LevelInfo, "Ping", map[string]interface{}{})
+	assert.Equal(t, "req-1", h.ctx.Value(ctxKey{}))
+
+	h.ctx = nil
+	lg.(sqldblogger.EventLogger).LogEvent(ctx, &sqldblogger.Event{Op: "Ping", Message: "Ping", Level: sqldblogger.LevelInfo})
+	assert.Equal(t, "req-1", h.ctx.Value(ctxKey{}))
+}
@@ -16,3 +16,5 @@ require (
 	go.uber.org/multierr v1.9.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+replace github.com/simukti/sqldb-logger => ../../
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 
 	sqldblogger "github.com/simukti/sqldb-logger"
 )
@@ -20,6 +21,11 @@ func New(logger *zap.Logger) sqldblogger.Logger {
 // Log implement sqldblogger.Logger and log it as is.
 // To use context.Context values, please copy this file and adjust to your needs.
 func (zp *zapAdapter) Log(_ context.Context, level sqldblogger.Level, msg string, data map[string]interface{}) {
+	ce := zp.logger.Check(zapLevel(level), msg)
+	if ce == nil {
+		return
+	}
+
 	fields := make([]zap.Field, len(data))
 	i := 0
 
@@ -28,15 +34,24 @@ func (zp *zapAdapter) Log(_ context.Context, level sqldblogger.Level, msg string
 		i++
 	}
 
+	ce.Write(fields...)
+}
+
+// Enabled implement sqldblogger.LevelEnabler using zap logger level.
+func (zp *zapAdapter) Enabled(_ context.Context, level sqldblogger.Level) bool {
+	return zp.logger.Core().Enabled(zapLevel(level))
+}
+
+func zapLevel(level sqldblogger.Level) zapcore.Level {
 	switch level {
 	case sqldblogger.LevelError:
-		zp.logger.Error(msg, fields...)
+		return zapcore.ErrorLevel
+	case sqldblogger.LevelWarn:
+		return zapcore.WarnLevel
 	case sqldblogger.LevelInfo:
-		zp.logger.Info(msg, fields...)
-	case sqldblogger.LevelDebug:
-		zp.logger.Debug(msg, fields...)
+		return zapcore.InfoLevel
 	default:
 		// trace will use zap debug
-		zp.logger.Debug(msg, fields...)
+		return zapcore.DebugLevel
 	}
 }
@@ -33,6 +33,7 @@ func TestZapAdapter_Log(t *testing.T) {
 
 	lvls := map[sqldblogger.Level]string{
 		sqldblogger.LevelError: "error",
+		sqldblogger.LevelWarn:  "warn",
 		sqldblogger.LevelInfo:  "info",
 		sqldblogger.LevelDebug: "debug",
 		sqldblogger.LevelTrace: "debug",
@@ -67,3 +68,19 @@ func TestZapAdapter_Log(t *testing.T) {
 		wr.Reset()
 	}
 }
+
+func TestZapAdapter_Enabled(t *testing.T) {
+	wr := &bytes.Buffer{}
+	enc := zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	lg := New(zap.New(zapcore.NewCore(enc, zapcore.AddSync(wr), zap.NewAtomicLevelAt(zap.WarnLevel))))
+	le, ok := lg.(sqldblogger.LevelEnabler)
+	assert.True(t, ok)
+
+	assert.True(t, le.Enabled(context.TODO(), sqldblogger.LevelError))
+	assert.True(t, le.Enabled(context.TODO(), sqldblogger.LevelWarn))
+	assert.False(t, le.Enabled(context.TODO(), sqldblogger.LevelInfo))
+	assert.False(t, le.Enabled(context.TODO(), sqldblogger.LevelTrace))
+
+	lg.Log(context.TODO(), sqldblogger.LevelInfo, "query", map[string]interface{}{"query": "SELECT 1"})
+	assert.Empty(t, wr.Bytes())
+}
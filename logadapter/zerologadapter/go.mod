@@ -17,3 +17,5 @@ require (
 	golang.org/x/sys v0.4.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+replace github.com/simukti/sqldb-logger => ../../
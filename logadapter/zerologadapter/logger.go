@@ -20,20 +20,29 @@ func New(logger zerolog.Logger) sqldblogger.Logger {
 // Log implement sqldblogger.Logger and log it as is.
 // To use context.Context values, please copy this file and adjust to your needs.
 func (zl *zerologAdapter) Log(_ context.Context, level sqldblogger.Level, msg string, data map[string]interface{}) {
-	var lvl zerolog.Level
+	zl.logger.WithLevel(zerologLevel(level)).Fields(data).Msg(msg)
+}
+
+// Enabled implement sqldblogger.LevelEnabler using zerolog logger level and global level.
+func (zl *zerologAdapter) Enabled(_ context.Context, level sqldblogger.Level) bool {
+	lvl := zerologLevel(level)
 
+	return lvl >= zl.logger.GetLevel() && lvl >= zerolog.GlobalLevel()
+}
+
+func zerologLevel(level sqldblogger.Level) zerolog.Level {
 	switch level {
 	case sqldblogger.LevelError:
-		lvl = zerolog.ErrorLevel
+		return zerolog.ErrorLevel
+	case sqldblogger.LevelWarn:
+		return zerolog.WarnLevel
 	case sqldblogger.LevelInfo:
-		lvl = zerolog.InfoLevel
+		return zerolog.InfoLevel
 	case sqldblogger.LevelDebug:
-		lvl = zerolog.DebugLevel
+		return zerolog.DebugLevel
 	case sqldblogger.LevelTrace:
-		lvl = zerolog.TraceLevel
+		return zerolog.TraceLevel
 	default:
-		lvl = zerolog.DebugLevel
+		return zerolog.DebugLevel
 	}
-
-	zl.logger.WithLevel(lvl).Fields(data).Msg(msg)
 }
@@ -29,6 +29,7 @@ func TestZerologAdapter_Log(t *testing.T) {
 	lg := New(zerolog.New(wr))
 	lvls := map[sqldblogger.Level]string{
 		sqldblogger.LevelError: "error",
+		sqldblogger.LevelWarn:  "warn",
 		sqldblogger.LevelInfo:  "info",
 		sqldblogger.LevelDebug: "debug",
 		sqldblogger.LevelTrace: "trace",
@@ -64,3 +65,20 @@ func TestZerologAdapter_Log(t *testing.T) {
 		wr.Reset()
 	}
 }
+
+func TestZerologAdapter_Enabled(t *testing.T) {
+	le, ok := New(zerolog.New(&bytes.Buffer{}).Level(zerolog.WarnLevel)).(sqldblogger.LevelEnabler)
+	assert.True(t, ok)
+
+	assert.True(t, le.Enabled(context.TODO(), sqldblogger.LevelError))
+	assert.True(t, le.Enabled(context.TODO(), sqldblogger.LevelWarn))
+	assert.False(t, le.Enabled(context.TODO(), sqldblogger.LevelInfo))
+	assert.False(t, le.Enabled(context.TODO(), sqldblogger.LevelTrace))
+
+	le = New(zerolog.New(&bytes.Buffer{})).(sqldblogger.LevelEnabler)
+	assert.True(t, le.Enabled(context.TODO(), sqldblogger.LevelTrace))
+
+	defer zerolog.SetGlobalLevel(zerolog.GlobalLevel())
+	zerolog.SetGlobalLevel(zerolog.ErrorLevel)
+	assert.False(t, le.Enabled(context.TODO(), sqldblogger.LevelWarn))
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql/driver"
 	"fmt"
 	"strconv"
+	"sync/atomic"
 	"time"
 )
 
@@ -19,6 +20,9 @@ const (
 	LevelDebug
 	// LevelInfo is used by Queryer, Execer, Preparer, and Stmt.
 	LevelInfo
+	// LevelWarn is used by long running call watchdog, and can be assigned by level rules (see: WithLevelRules)
+	// or slow query threshold (see: WithSlowQueryThreshold).
+	LevelWarn
 	// LevelError is used on actual driver error or when driver not implement some optional sql/driver interface.
 	LevelError
 )
@@ -33,6 +37,8 @@ func (l Level) String() string {
 		return "debug"
 	case LevelInfo:
 		return "info"
+	case LevelWarn:
+		return "warn"
 	case LevelError:
 		return "error"
 	default:
@@ -46,127 +52,306 @@ type Logger interface {
 	Log(ctx context.Context, level Level, msg string, data map[string]interface{})
 }
 
+// LevelEnabler is optional Logger interface to report whether given level is enabled by the logger backend.
+// Logger which implements it is asked before any log data built, so log with level disabled by the backend
+// costs nothing even if it pass minimum level option (see: WithMinimumLevel).
+//
+// Enabled must be safe for concurrent use.
+type LevelEnabler interface {
+	Enabled(ctx context.Context, level Level) bool
+}
+
 // logger internal logger wrapper
 type logger struct {
 	logger Logger
 	opt    *options
+	// live is options changed at runtime by Controller, it replaces opt once set (see: options()).
+	live     atomic.Value
+	watchdog *watchdog
 }
 
-// dataFunc for extra data to be added to log
-type dataFunc func() (string, interface{})
+// options return current options snapshot, it is safe for concurrent use with update().
+func (l *logger) options() *options {
+	if opt, ok := l.live.Load().(*options); ok {
+		return opt
+	}
+
+	return l.opt
+}
 
-// withUID used to set unique id per call scope.
-func (l *logger) withUID(k, v string) dataFunc {
-	return func() (string, interface{}) {
-		if v == "" {
-			return k, nil
-		}
+// update apply given options to a copy of current options, then replace current options atomically.
+// Concurrent update must be serialized by the caller (see: Controller).
+func (l *logger) update(opts ...Option) {
+	opt := *l.options()
 
-		return k, v
+	for _, o := range opts {
+		o(&opt)
 	}
+
+	l.live.Store(&opt)
 }
 
-func (l *logger) withQuery(query string) dataFunc {
-	return func() (string, interface{}) {
-		return l.opt.sqlQueryFieldname, query
+// levelEnabler return LevelEnabler if Logger implements it, including Logger wrapped by asynchronous logger.
+func (l *logger) levelEnabler() (LevelEnabler, bool) {
+	lg := l.logger
+	if a, ok := lg.(*asyncLogger); ok {
+		lg = a.logger
 	}
+
+	le, ok := lg.(LevelEnabler)
+
+	return le, ok
 }
 
-func (l *logger) withArgs(args []driver.Value) dataFunc {
-	return func() (string, interface{}) {
-		if !l.opt.logArgs {
-			return l.opt.sqlArgsFieldname, nil
-		}
+// watch track in-flight call by watchdog (if enabled) until returned func called.
+func (l *logger) watch(op, connID, txID, stmtID, query string) func() {
+	return l.watchdog.watch(op, connID, txID, stmtID, query)
+}
 
-		return l.withKeyArgs(l.opt.sqlArgsFieldname, args)()
+// maskQuery return logged query, which is masked if WithMaskSQLLiterals enabled.
+func maskQuery(opt *options, query string) string {
+	if opt.maskSQLLiterals {
+		return MaskSQLLiterals(query, opt.sqlDialect)
 	}
+
+	return query
 }
 
-func (l *logger) withKeyArgs(key string, args []driver.Value) dataFunc {
-	return func() (string, interface{}) {
-		if len(args) == 0 {
-			return key, nil
+// namedArgs return logged named arguments by name from logged values, nil if none.
+func namedArgs(args []driver.NamedValue, values []interface{}) map[string]interface{} {
+	var named map[string]interface{}
+
+	for i, a := range args {
+		if a.Name == "" || i >= len(values) {
+			continue
+		}
+
+		if named == nil {
+			named = make(map[string]interface{})
 		}
 
-		return key, parseArgs(args)
+		named[a.Name] = values[i]
 	}
+
+	return named
 }
 
-func (l *logger) log(ctx context.Context, lvl Level, msg string, start time.Time, err error, datas ...dataFunc) {
-	if lvl < l.opt.minimumLogLevel {
-		return
-	}
+// logCall log intercepted driver call (see: logger.intercept), or a call which only carry log data (e.g: leak report).
+// Log enablement is checked before any allocation, then call log data is set directly to a pooled event.
+// Given fielder (if not nil) add call specific fields.
+func (l *logger) logCall(call *Call, lvl Level, fielder eventFielder) {
+	opt := l.options()
 
-	if !l.opt.logDriverErrSkip && err == driver.ErrSkip {
+	override := overrideFromContext(call.Ctx)
+	if skipped(opt, override, call.Err) {
 		return
 	}
 
-	data := map[string]interface{}{
-		l.opt.timeFieldname:     l.opt.timeFormat.format(time.Now()),
-		l.opt.durationFieldname: l.opt.durationUnit.format(time.Since(start)),
+	query := call.Query
+	re := ruleEvent{op: call.Op, connID: call.ConnID, duration: time.Since(call.Start), err: call.Err, dialect: opt.sqlDialect}
+
+	if len(opt.levelRules) > 0 {
+		query = maskQuery(opt, query)
+		re.query = query
 	}
 
-	if l.opt.includeStartTime {
-		data[l.opt.startTimeFieldname] = l.opt.timeFormat.format(start)
+	lvl, slow, ok := l.enabled(call.Ctx, opt, override, &re, lvl)
+	if !ok {
+		return
 	}
 
-	if lvl == LevelError && err != nil {
-		data[l.opt.errorFieldname] = err.Error()
+	if len(opt.levelRules) == 0 {
+		query = maskQuery(opt, query)
 	}
 
-	for _, d := range datas {
-		k, v := d()
+	_, typed := l.eventLogger()
+	e := newEvent(opt)
+	e.Op, e.Message, e.Level = call.Op, call.Op, lvl
+	e.Time, e.Start, e.Duration = time.Now(), call.Start, re.duration
+	e.Err, e.Slow = call.Err, slow
+	e.ConnID, e.TxID, e.StmtID, e.Query = call.ConnID, call.TxID, call.StmtID, query
 
-		if k == l.opt.sqlArgsFieldname && !l.opt.logArgs {
-			continue
+	if opt.logArgs && len(call.Args) > 0 {
+		e.Args = appendArgs(opt, e.argsBuf[:0], call.Query, call.Args)
+
+		if typed {
+			e.NamedArgs = namedArgs(call.Args, e.Args)
 		}
+	}
 
-		// don't log nil value
-		if v == nil {
-			continue
+	if typed && call.Result != nil {
+		if num, err := call.Result.RowsAffected(); err == nil {
+			e.RowsAffected = num
 		}
+	}
 
-		if k == l.opt.sqlQueryFieldname && l.opt.sqlQueryAsMsg {
-			msg = v.(string)
-			continue
+	for k, v := range call.fields {
+		if v != nil && !isLoggerField(opt, k) {
+			e.fields()[k] = v
 		}
+	}
 
-		data[k] = v
+	if fielder != nil {
+		fielder.eventFields(e, call)
 	}
 
-	l.logger.Log(ctx, lvl, msg, data)
+	l.emit(call.Ctx, opt, override, e)
+}
+
+// eventFielder add call specific fields to call log event (e.g: rows and transaction summary).
+type eventFielder interface {
+	eventFields(e *Event, call *Call)
+}
+
+// skipped return true if log is silenced by context, or given error is driver.ErrSkip and not logged.
+func skipped(opt *options, override *callOverride, err error) bool {
+	return override.silenced() || (!opt.logDriverErrSkip && err == driver.ErrSkip)
+}
+
+// enabled return final level of given event (by level rules and slow query threshold), whether it is slow,
+// and false if the level is below minimum level or disabled by Logger (see: LevelEnabler).
+func (l *logger) enabled(ctx context.Context, opt *options, override *callOverride, re *ruleEvent, lvl Level) (Level, bool, bool) {
+	if len(opt.levelRules) > 0 {
+		lvl = levelByRules(opt.levelRules, re, lvl)
+	}
+
+	slow := l.isSlow(re.op, re.duration)
+
+	if slow && lvl < opt.slowQueryLevel {
+		lvl = opt.slowQueryLevel
+	}
+
+	if lvl < override.minimumLevel(opt.minimumLogLevel) {
+		return lvl, slow, false
+	}
+
+	if le, ok := l.levelEnabler(); ok && !le.Enabled(ctx, lvl) {
+		return lvl, slow, false
+	}
+
+	return lvl, slow, true
+}
+
+// emit add context data (tags, context fields, fingerprint) to given event, then deliver it if not sampled out.
+// The event is released after delivered.
+func (l *logger) emit(ctx context.Context, opt *options, override *callOverride, e *Event) {
+	defer e.release()
+
+	if opt.sqlQueryAsMsg && e.Query != "" {
+		e.Message = e.Query
+	}
+
+	if override != nil && len(override.tags) > 0 {
+		override.withTags(e.fields())
+	}
+
+	if len(opt.contextFields) > 0 {
+		l.withContextFields(ctx, e.fields())
+	}
+
+	var fingerprint string
+
+	if e.Query != "" && (opt.queryFingerprint || opt.sampler != nil) {
+		fingerprint = FingerprintSQL(e.Query, opt.sqlDialect)
+	}
+
+	if opt.queryFingerprint && fingerprint != "" {
+		e.Fingerprint, e.FingerprintHash = fingerprint, FingerprintHash(fingerprint)
+	}
+
+	if opt.sampler != nil && !l.sample(ctx, SampleEvent{Level: e.Level, Message: e.Op, Fingerprint: fingerprint, Duration: e.Duration, Slow: e.Slow, Err: e.Err}) {
+		return
+	}
+
+	l.deliver(ctx, e)
+}
+
+// isLoggerField check if given fieldname is sqldblogger call fieldname.
+func isLoggerField(opt *options, k string) bool {
+	switch k {
+	case opt.connIDFieldname, opt.txIDFieldname, opt.stmtIDFieldname, opt.sqlQueryFieldname, opt.sqlArgsFieldname:
+		return true
+	default:
+		return false
+	}
+}
+
+// withContextFields add fields from context extractors to data, existing field is not overridden.
+func (l *logger) withContextFields(ctx context.Context, data map[string]interface{}) {
+	if ctx == nil {
+		return
+	}
+
+	for _, fn := range l.options().contextFields {
+		for k, v := range fn(ctx) {
+			if _, ok := data[k]; ok || v == nil {
+				continue
+			}
+
+			data[k] = v
+		}
+	}
+}
+
+// slowQueryOps is operations subject to slow query threshold.
+var slowQueryOps = map[string]bool{
+	"Exec":             true,
+	"ExecContext":      true,
+	"Query":            true,
+	"QueryContext":     true,
+	"Prepare":          true,
+	"PrepareContext":   true,
+	"StmtExec":         true,
+	"StmtExecContext":  true,
+	"StmtQuery":        true,
+	"StmtQueryContext": true,
+	"Commit":           true,
+	"Rollback":         true,
+}
+
+// isSlow check if given operation took longer than slow query threshold.
+func (l *logger) isSlow(op string, duration time.Duration) bool {
+	opt := l.options()
+	if opt.slowQueryThreshold <= 0 || duration < opt.slowQueryThreshold {
+		return false
+	}
+
+	return slowQueryOps[op]
 }
 
 // maxArgValueLen []byte and string more than this length will be truncated.
 const maxArgValueLen int = 64
 
-// parseArgs will trim argument value if it is []byte or string more than maxArgValueLen.
+// truncateArg trim []byte or string argument value more than maxArgValueLen.
 // Copied from https://github.com/jackc/pgx/blob/f3a3ee1a0e5c8fc8991928bcd06fdbcd1ee9d05c/logger.go#L79
 // and modified accordingly.
-func parseArgs(argsVal []driver.Value) []interface{} {
-	args := make([]interface{}, len(argsVal))
-
-	for k, a := range argsVal {
-		switch v := a.(type) {
-		case []byte:
-			if len(v) < maxArgValueLen {
-				a = string(v)
-			} else {
-				a = string(v[:maxArgValueLen]) + " (" + strconv.Itoa(len(v)-maxArgValueLen) + " bytes truncated)"
-			}
-		case string:
-			if len(v) > maxArgValueLen {
-				a = v[:maxArgValueLen] + " (" + strconv.Itoa(len(v)-maxArgValueLen) + " bytes truncated)"
-			}
+func truncateArg(a driver.Value) interface{} {
+	switch v := a.(type) {
+	case []byte:
+		if len(v) < maxArgValueLen {
+			return string(v)
 		}
 
-		args[k] = a
+		return string(v[:maxArgValueLen]) + " (" + strconv.Itoa(len(v)-maxArgValueLen) + " bytes truncated)"
+	case string:
+		if len(v) > maxArgValueLen {
+			return v[:maxArgValueLen] + " (" + strconv.Itoa(len(v)-maxArgValueLen) + " bytes truncated)"
+		}
 	}
 
-	return args
+	return a
 }
 
-// namedValuesToValues is type conversion ONLY for logging arguments.
+// appendArgs append redacted (see: WithArgRedactor) and truncated arguments to given buffer.
+func appendArgs(opt *options, buf []interface{}, query string, args []driver.NamedValue) []interface{} {
+	for _, a := range args {
+		buf = append(buf, truncateArg(redactArg(opt, query, a.Ordinal, a.Name, a.Value)))
+	}
+
+	return buf
+}
+
+// namedValuesToValues convert (possibly rewritten by interceptor) named values back to legacy driver values.
 func namedValuesToValues(args []driver.NamedValue) []driver.Value {
 	argsVal := make([]driver.Value, len(args))
 
@@ -176,3 +361,14 @@ func namedValuesToValues(args []driver.NamedValue) []driver.Value {
 
 	return argsVal
 }
+
+// valuesToNamedValues convert legacy driver values to named values for logging and interceptors, ordinal position starts from 1.
+func valuesToNamedValues(args []driver.Value) []driver.NamedValue {
+	argsVal := make([]driver.NamedValue, len(args))
+
+	for k, v := range args {
+		argsVal[k] = driver.NamedValue{Ordinal: k + 1, Value: v}
+	}
+
+	return argsVal
+}
@@ -15,6 +15,7 @@ import (
 func TestLevel_String(t *testing.T) {
 	tt := map[Level]string{
 		LevelError: "error",
+		LevelWarn:  "warn",
 		LevelInfo:  "info",
 		LevelDebug: "debug",
 		LevelTrace: "trace",
@@ -32,38 +33,34 @@ func TestNullLogger_Log(t *testing.T) {
 	assert.Implements(t, (*Logger)(nil), lg)
 }
 
-func TestWithQuery(t *testing.T) {
+func TestMaskQuery(t *testing.T) {
 	cfg := &options{}
 	setDefaultOptions(cfg)
-	l := &logger{opt: cfg}
-	k, v := l.withQuery("query")()
-	assert.Equal(t, cfg.sqlQueryFieldname, k)
-	assert.Equal(t, "query", fmt.Sprint(v))
+	q := `SELECT * FROM users WHERE email = "x@y.com" AND id = 1`
+	assert.Equal(t, q, maskQuery(cfg, q))
+
+	WithMaskSQLLiterals(true)(cfg)
+	WithSQLDialect(SQLDialectMySQL)(cfg)
+	assert.Equal(t, "SELECT * FROM users WHERE email = ? AND id = ?", maskQuery(cfg, q))
 }
 
-func TestWithArgs(t *testing.T) {
+func TestAppendArgs(t *testing.T) {
 	cfg := &options{}
 	setDefaultOptions(cfg)
-	l := &logger{opt: cfg}
 
 	t.Run("Non Empty Args", func(t *testing.T) {
-		k, v := l.withArgs([]driver.Value{1})()
-		assert.Equal(t, cfg.sqlArgsFieldname, k)
-		assert.Equal(t, []interface{}{1}, v)
+		assert.Equal(t, []interface{}{1}, appendArgs(cfg, nil, "query", valuesToNamedValues([]driver.Value{1})))
 	})
 
 	t.Run("Non Empty Named Args", func(t *testing.T) {
-		k, v := l.withArgs(namedValuesToValues([]driver.NamedValue{
+		args := appendArgs(cfg, nil, "query", []driver.NamedValue{
 			{Name: "test", Ordinal: 1, Value: 9},
-		}))()
-		assert.Equal(t, cfg.sqlArgsFieldname, k)
-		assert.Equal(t, []interface{}{9}, v)
+		})
+		assert.Equal(t, []interface{}{9}, args)
 	})
 
 	t.Run("Empty Args", func(t *testing.T) {
-		k, v := l.withArgs([]driver.Value{})()
-		assert.Equal(t, cfg.sqlArgsFieldname, k)
-		assert.Equal(t, nil, v)
+		assert.Nil(t, appendArgs(cfg, nil, "query", valuesToNamedValues([]driver.Value{})))
 	})
 }
 
@@ -129,7 +126,7 @@ func TestLogInternalWithMinimumLevel(t *testing.T) {
 		WithMinimumLevel(tc.minLevel)(cfg)
 		bl := &bufferTestLogger{}
 		l := &logger{opt: cfg, logger: bl}
-		l.log(context.TODO(), tc.givenLevel, tc.msg, time.Now(), tc.err)
+		l.logCall(&Call{Ctx: context.TODO(), Op: tc.msg, Start: time.Now(), Err: tc.err}, tc.givenLevel, nil)
 		if tc.expect == "" {
 			assert.Equal(t, bl.String(), tc.expect)
 		} else {
@@ -147,7 +144,7 @@ func TestLogInternal(t *testing.T) {
 	setDefaultOptions(cfg)
 	bl := &bufferTestLogger{}
 	l := &logger{opt: cfg, logger: bl}
-	l.log(context.TODO(), LevelInfo, "msg", time.Now(), nil)
+	l.logCall(&Call{Ctx: context.TODO(), Op: "msg", Start: time.Now()}, LevelInfo, nil)
 
 	var content bufLog
 	err := json.Unmarshal(bl.Bytes(), &content)
@@ -163,7 +160,7 @@ func TestLogInternalWithData(t *testing.T) {
 	setDefaultOptions(cfg)
 	bl := &bufferTestLogger{}
 	l := &logger{opt: cfg, logger: bl}
-	l.log(context.TODO(), LevelInfo, "msg", time.Now(), nil, l.withQuery("query"))
+	l.logCall(&Call{Ctx: context.TODO(), Op: "msg", Start: time.Now(), Query: "query"}, LevelInfo, nil)
 
 	var content bufLog
 	err := json.Unmarshal(bl.Bytes(), &content)
@@ -182,7 +179,7 @@ func TestLogInternalErrorLevel(t *testing.T) {
 	setDefaultOptions(cfg)
 	bl := &bufferTestLogger{}
 	l := &logger{opt: cfg, logger: bl}
-	l.log(context.TODO(), LevelError, "msg", time.Now(), fmt.Errorf("dummy"), l.withQuery("query"))
+	l.logCall(&Call{Ctx: context.TODO(), Op: "msg", Start: time.Now(), Err: fmt.Errorf("dummy"), Query: "query"}, LevelError, nil)
 
 	var content bufLog
 	err := json.Unmarshal(bl.Bytes(), &content)
@@ -201,20 +198,11 @@ func TestLogTrimStringArgs(t *testing.T) {
 	longArgVal := "Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua."
 	bl := &bufferTestLogger{}
 	l := &logger{opt: cfg, logger: bl}
-	l.log(
-		context.TODO(),
-		LevelInfo,
-		"msg",
-		time.Now(),
-		nil,
-		l.withUID(cfg.stmtIDFieldname, ""),
-		l.withQuery("query"),
-		l.withArgs([]driver.Value{
-			longArgVal,
-			[]byte(longArgVal),
-			[]byte("short"),
-		}),
-	)
+	l.logCall(&Call{Ctx: context.TODO(), Op: "msg", Start: time.Now(), Query: "query", Args: valuesToNamedValues([]driver.Value{
+		longArgVal,
+		[]byte(longArgVal),
+		[]byte("short"),
+	})}, LevelInfo, nil)
 
 	var content bufLog
 	err := json.Unmarshal(bl.Bytes(), &content)
@@ -248,19 +236,11 @@ func TestWithLogArgumentsFalse(t *testing.T) {
 
 	bl := &bufferTestLogger{}
 	l := &logger{opt: cfg, logger: bl}
-	l.log(
-		context.TODO(),
-		LevelInfo,
-		"msg",
-		time.Now(),
-		nil,
-		l.withQuery("query"),
-		l.withArgs([]driver.Value{
-			1,
-			[]byte("kedua"),
-			[]byte("lanjut"),
-		}),
-	)
+	l.logCall(&Call{Ctx: context.TODO(), Op: "msg", Start: time.Now(), Query: "query", Args: valuesToNamedValues([]driver.Value{
+		1,
+		[]byte("kedua"),
+		[]byte("lanjut"),
+	})}, LevelInfo, nil)
 
 	var content bufLog
 	err := json.Unmarshal(bl.Bytes(), &content)
@@ -279,15 +259,7 @@ func TestWithEmptyArgs(t *testing.T) {
 
 	bl := &bufferTestLogger{}
 	l := &logger{opt: cfg, logger: bl}
-	l.log(
-		context.TODO(),
-		LevelInfo,
-		"msg",
-		time.Now(),
-		nil,
-		l.withQuery("query"),
-		l.withArgs([]driver.Value{}),
-	)
+	l.logCall(&Call{Ctx: context.TODO(), Op: "msg", Start: time.Now(), Query: "query", Args: valuesToNamedValues([]driver.Value{})}, LevelInfo, nil)
 
 	var content bufLog
 	err := json.Unmarshal(bl.Bytes(), &content)
@@ -306,13 +278,7 @@ func TestWithErrorDriverSkip(t *testing.T) {
 	l := &logger{opt: cfg, logger: bl}
 
 	t.Run("Skip", func(t *testing.T) {
-		l.log(
-			context.TODO(),
-			LevelError,
-			"msg",
-			time.Now(),
-			driver.ErrSkip,
-		)
+		l.logCall(&Call{Ctx: context.TODO(), Op: "msg", Start: time.Now(), Err: driver.ErrSkip}, LevelError, nil)
 
 		assert.Empty(t, bl.Bytes())
 	})
@@ -320,13 +286,7 @@ func TestWithErrorDriverSkip(t *testing.T) {
 	t.Run("No Skip", func(t *testing.T) {
 		WithLogDriverErrorSkip(true)(cfg)
 
-		l.log(
-			context.TODO(),
-			LevelError,
-			"msg",
-			time.Now(),
-			driver.ErrSkip,
-		)
+		l.logCall(&Call{Ctx: context.TODO(), Op: "msg", Start: time.Now(), Err: driver.ErrSkip}, LevelError, nil)
 
 		var content bufLog
 		err := json.Unmarshal(bl.Bytes(), &content)
@@ -345,16 +305,7 @@ func TestWithSQLQueryAsMessage2(t *testing.T) {
 
 	WithSQLQueryAsMessage(true)(cfg)
 
-	l.log(
-		context.TODO(),
-		LevelInfo,
-		"msg",
-		time.Now(),
-		nil,
-		testLogger.withUID(cfg.stmtIDFieldname, l.opt.uidGenerator.UniqueID()),
-		testLogger.withQuery("query"),
-		testLogger.withArgs([]driver.Value{}),
-	)
+	l.logCall(&Call{Ctx: context.TODO(), Op: "msg", Start: time.Now(), StmtID: l.opt.uidGenerator.UniqueID(), Query: "query", Args: valuesToNamedValues([]driver.Value{})}, LevelInfo, nil)
 
 	var content bufLog
 	err := json.Unmarshal(bl.Bytes(), &content)
@@ -368,6 +319,87 @@ func TestWithSQLQueryAsMessage2(t *testing.T) {
 	assert.NotContains(t, content.Data, cfg.sqlArgsFieldname)
 }
 
+func TestLogInternalSlowQuery(t *testing.T) {
+	cfg := &options{}
+	setDefaultOptions(cfg)
+	WithMinimumLevel(LevelError)(cfg)
+	WithSlowQueryThreshold(time.Millisecond, LevelError)(cfg)
+	bl := &bufferTestLogger{}
+	l := &logger{opt: cfg, logger: bl}
+
+	t.Run("Fast Query", func(t *testing.T) {
+		l.logCall(&Call{Ctx: context.TODO(), Op: "QueryContext", Start: time.Now(), Query: "query"}, LevelInfo, nil)
+		assert.Empty(t, bl.Bytes())
+	})
+
+	t.Run("Slow Query", func(t *testing.T) {
+		l.logCall(&Call{Ctx: context.TODO(), Op: "QueryContext", Start: time.Now().Add(-time.Second), Query: "query"}, LevelInfo, nil)
+
+		var content bufLog
+		err := json.Unmarshal(bl.Bytes(), &content)
+		assert.NoError(t, err)
+		assert.Equal(t, LevelError.String(), content.Level)
+		assert.Equal(t, "QueryContext", content.Message)
+		assert.Equal(t, true, content.Data[cfg.slowQueryFieldname])
+		assert.NotContains(t, content.Data, cfg.errorFieldname)
+		bl.Reset()
+	})
+
+	t.Run("Slow Non Query Operation", func(t *testing.T) {
+		l.logCall(&Call{Ctx: context.TODO(), Op: "Ping", Start: time.Now().Add(-time.Second)}, LevelDebug, nil)
+		assert.Empty(t, bl.Bytes())
+	})
+
+	t.Run("Slow Query Never Lower Level", func(t *testing.T) {
+		WithMinimumLevel(LevelTrace)(cfg)
+		WithSlowQueryThreshold(time.Millisecond, LevelDebug)(cfg)
+		l.logCall(&Call{Ctx: context.TODO(), Op: "ExecContext", Start: time.Now().Add(-time.Second), Err: fmt.Errorf("dummy")}, LevelError, nil)
+
+		var content bufLog
+		err := json.Unmarshal(bl.Bytes(), &content)
+		assert.NoError(t, err)
+		assert.Equal(t, LevelError.String(), content.Level)
+		assert.Equal(t, true, content.Data[cfg.slowQueryFieldname])
+		assert.Contains(t, content.Data, cfg.errorFieldname)
+		bl.Reset()
+	})
+}
+
+type ctxFieldKey struct{}
+
+// ctxFieldsTest is WithContextFields extractor for tests, it add request_id from context.
+func ctxFieldsTest(ctx context.Context) map[string]interface{} {
+	if id, ok := ctx.Value(ctxFieldKey{}).(string); ok {
+		return map[string]interface{}{"request_id": id, "query": "not overridden", "empty": nil}
+	}
+
+	return nil
+}
+
+func TestLogInternalContextFields(t *testing.T) {
+	cfg := &options{}
+	setDefaultOptions(cfg)
+	WithContextFields(ctxFieldsTest)(cfg)
+	bl := &bufferTestLogger{}
+	l := &logger{opt: cfg, logger: bl}
+
+	ctx := context.WithValue(context.TODO(), ctxFieldKey{}, "req-1")
+	l.logCall(&Call{Ctx: ctx, Op: "QueryContext", Start: time.Now(), Query: "SELECT 1"}, LevelInfo, nil)
+
+	var content bufLog
+	err := json.Unmarshal(bl.Bytes(), &content)
+	assert.NoError(t, err)
+	assert.Equal(t, "req-1", content.Data["request_id"])
+	assert.Equal(t, "SELECT 1", content.Data[cfg.sqlQueryFieldname])
+	assert.NotContains(t, content.Data, "empty")
+
+	l.logCall(&Call{Ctx: context.TODO(), Op: "QueryContext", Start: time.Now(), Query: "SELECT 1"}, LevelInfo, nil)
+	content = bufLog{}
+	err = json.Unmarshal(bl.Bytes(), &content)
+	assert.NoError(t, err)
+	assert.NotContains(t, content.Data, "request_id")
+}
+
 type bufferTestLogger struct {
 	bytes.Buffer
 }
@@ -382,3 +414,225 @@ func (bl *bufferTestLogger) Log(_ context.Context, level Level, msg string, data
 	bl.Reset()
 	_ = json.NewEncoder(bl).Encode(bufLog{level.String(), msg, data})
 }
+
+// noopLogger discard every log.
+type noopLogger struct{}
+
+func (noopLogger) Log(_ context.Context, _ Level, _ string, _ map[string]interface{}) {}
+
+// noopEventLogger discard every typed event.
+type noopEventLogger struct{ noopLogger }
+
+func (noopEventLogger) LogEvent(_ context.Context, _ *Event) {}
+
+// noopDriverConn is driver connection which does nothing, used to measure logger overhead.
+type noopDriverConn struct{}
+
+var noopResult driver.Result = driver.RowsAffected(1)
+
+func (noopDriverConn) Prepare(_ string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (noopDriverConn) Close() error                          { return nil }
+func (noopDriverConn) Begin() (driver.Tx, error)             { return nil, driver.ErrSkip }
+func (noopDriverConn) ExecContext(_ context.Context, _ string, _ []driver.NamedValue) (driver.Result, error) {
+	return noopResult, nil
+}
+func (noopDriverConn) QueryContext(_ context.Context, _ string, _ []driver.NamedValue) (driver.Rows, error) {
+	return noopDriverRows{}, nil
+}
+func (noopDriverConn) CheckNamedValue(_ *driver.NamedValue) error { return nil }
+
+// noopDriverRows is endless driver rows which does nothing.
+type noopDriverRows struct{}
+
+func (noopDriverRows) Columns() []string              { return []string{"id", "name"} }
+func (noopDriverRows) Close() error                   { return nil }
+func (noopDriverRows) Next(dest []driver.Value) error { return nil }
+
+var noopArgs = []driver.NamedValue{{Ordinal: 1, Value: int64(1)}, {Name: "name", Ordinal: 2, Value: "name"}}
+
+const noopQuery = "UPDATE tt SET name = :name WHERE id = ?"
+
+// noopConnection wrap noop driver connection with given logger and options.
+func noopConnection(lg Logger, opt ...Option) *connection {
+	return &connection{Conn: noopDriverConn{}, logger: newLogger(lg, opt...), id: "conn"}
+}
+
+// levelEnablerTestLogger enable log at given level and above, and record enabled checks and logs.
+type levelEnablerTestLogger struct {
+	syncTestLogger
+	level   Level
+	checked []Level
+}
+
+func (ll *levelEnablerTestLogger) Enabled(_ context.Context, level Level) bool {
+	ll.mu.Lock()
+	defer ll.mu.Unlock()
+
+	ll.checked = append(ll.checked, level)
+
+	return level >= ll.level
+}
+
+func TestLevelEnabler(t *testing.T) {
+	ll := &levelEnablerTestLogger{level: LevelWarn}
+	l := newLogger(ll, WithMinimumLevel(LevelTrace))
+
+	l.logCall(&Call{Ctx: context.TODO(), Op: "QueryContext", Start: time.Now(), Query: "SELECT 1"}, LevelInfo, nil)
+	assert.Empty(t, ll.get())
+
+	l.logCall(&Call{Ctx: context.TODO(), Op: "QueryContext", Start: time.Now(), Err: driver.ErrBadConn, Query: "SELECT 1"}, LevelError, nil)
+	assert.Len(t, ll.get(), 1)
+	assert.Equal(t, []Level{LevelInfo, LevelError}, ll.checked)
+
+	t.Run("Async", func(t *testing.T) {
+		ll := &levelEnablerTestLogger{level: LevelWarn}
+		l := newLogger(ll, WithMinimumLevel(LevelTrace), WithAsyncLogger(10, DropPolicyBlock))
+
+		l.logCall(&Call{Ctx: context.TODO(), Op: "QueryContext", Start: time.Now(), Query: "SELECT 1"}, LevelInfo, nil)
+		assert.NoError(t, l.logger.(*asyncLogger).Close())
+		assert.Empty(t, ll.get())
+		assert.Equal(t, []Level{LevelInfo}, ll.checked)
+	})
+
+	t.Run("MinimumLevel", func(t *testing.T) {
+		ll := &levelEnablerTestLogger{level: LevelTrace}
+		l := newLogger(ll, WithMinimumLevel(LevelInfo))
+
+		l.logCall(&Call{Ctx: context.TODO(), Op: "Ping", Start: time.Now()}, LevelDebug, nil)
+		assert.Empty(t, ll.checked)
+	})
+}
+
+func TestLogAllocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("sync.Pool randomly drop items with race detector")
+	}
+
+	ctx, dest := context.TODO(), make([]driver.Value, 2)
+
+	t.Run("Disabled", func(t *testing.T) {
+		conn := noopConnection(noopLogger{}, WithMinimumLevel(LevelError), WithWrapResult(false))
+		r := &rows{Rows: noopDriverRows{}, ctx: ctx, logger: conn.logger, connID: conn.id, query: noopQuery, args: noopArgs}
+
+		assert.Zero(t, testing.AllocsPerRun(100, func() { _, _ = conn.ExecContext(ctx, noopQuery, noopArgs) }))
+		assert.Zero(t, testing.AllocsPerRun(100, func() { _, _ = conn.QueryContext(ctx, noopQuery, noopArgs) }))
+		assert.Zero(t, testing.AllocsPerRun(100, func() { _ = r.Next(dest) }))
+		assert.Zero(t, testing.AllocsPerRun(100, func() { _ = conn.CheckNamedValue(&noopArgs[0]) }))
+	})
+
+	t.Run("DisabledByLogger", func(t *testing.T) {
+		conn := noopConnection(&levelEnablerTestLogger{level: LevelError}, WithMinimumLevel(LevelTrace), WithWrapResult(false))
+		conn.logger.logger.(*levelEnablerTestLogger).checked = make([]Level, 0, 1000)
+
+		assert.Zero(t, testing.AllocsPerRun(100, func() { _, _ = conn.ExecContext(ctx, noopQuery, noopArgs) }))
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		args := []driver.NamedValue{{Ordinal: 1, Value: int64(1)}, {Ordinal: 2, Value: int64(2)}, {Ordinal: 3, Value: int64(3)}}
+
+		for _, lg := range []Logger{noopLogger{}, noopEventLogger{}} {
+			conn := noopConnection(lg, WithMinimumLevel(LevelTrace), WithWrapResult(false))
+			oneArg := testing.AllocsPerRun(100, func() { _, _ = conn.ExecContext(ctx, noopQuery, args[:1]) })
+			threeArgs := testing.AllocsPerRun(100, func() { _, _ = conn.ExecContext(ctx, noopQuery, args) })
+
+			assert.LessOrEqual(t, threeArgs, float64(8), "%T", lg)
+			assert.Equal(t, oneArg, threeArgs, "%T", lg)
+		}
+	})
+}
+
+func BenchmarkConnection_ExecContext(b *testing.B) {
+	ctx := context.TODO()
+
+	b.Run("Driver", func(b *testing.B) {
+		conn := noopDriverConn{}
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			_, _ = conn.ExecContext(ctx, noopQuery, noopArgs)
+		}
+	})
+
+	for _, bb := range []struct {
+		name string
+		lg   Logger
+		lvl  Level
+	}{
+		{"Disabled", noopLogger{}, LevelError},
+		{"Enabled", noopLogger{}, LevelTrace},
+		{"EnabledEvent", noopEventLogger{}, LevelTrace},
+	} {
+		conn := noopConnection(bb.lg, WithMinimumLevel(bb.lvl), WithWrapResult(false))
+
+		b.Run(bb.name, func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				_, _ = conn.ExecContext(ctx, noopQuery, noopArgs)
+			}
+		})
+	}
+}
+
+func BenchmarkConnection_QueryContext(b *testing.B) {
+	ctx := context.TODO()
+
+	b.Run("Driver", func(b *testing.B) {
+		conn := noopDriverConn{}
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			_, _ = conn.QueryContext(ctx, noopQuery, noopArgs)
+		}
+	})
+
+	for _, bb := range []struct {
+		name string
+		lvl  Level
+	}{
+		{"Disabled", LevelError},
+		{"Enabled", LevelTrace},
+	} {
+		conn := noopConnection(noopLogger{}, WithMinimumLevel(bb.lvl))
+
+		b.Run(bb.name, func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				_, _ = conn.QueryContext(ctx, noopQuery, noopArgs)
+			}
+		})
+	}
+}
+
+func BenchmarkRows_Next(b *testing.B) {
+	dest := make([]driver.Value, 2)
+
+	b.Run("Driver", func(b *testing.B) {
+		r := noopDriverRows{}
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			_ = r.Next(dest)
+		}
+	})
+
+	for _, bb := range []struct {
+		name string
+		lvl  Level
+	}{
+		{"Disabled", LevelError},
+		{"Enabled", LevelTrace},
+	} {
+		l := newLogger(noopLogger{}, WithMinimumLevel(bb.lvl))
+		r := &rows{Rows: noopDriverRows{}, ctx: context.TODO(), logger: l, connID: "conn", query: noopQuery, args: noopArgs}
+
+		b.Run(bb.name, func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				_ = r.Next(dest)
+			}
+		})
+	}
+}
@@ -0,0 +1,7 @@
+//go:build !race
+// +build !race
+
+package sqldblogger
+
+// raceEnabled is true when tests run with race detector, which randomly drop sync.Pool items.
+const raceEnabled = false
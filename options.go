@@ -1,6 +1,7 @@
 package sqldblogger
 
 import (
+	"context"
 	cryptoRand "crypto/rand"
 	"encoding/binary"
 	"fmt"
@@ -9,27 +10,50 @@ import (
 )
 
 type options struct {
-	errorFieldname     string
-	durationFieldname  string
-	timeFieldname      string
-	startTimeFieldname string
-	sqlQueryFieldname  string
-	sqlArgsFieldname   string
-	stmtIDFieldname    string
-	connIDFieldname    string
-	txIDFieldname      string
-	sqlQueryAsMsg      bool
-	logArgs            bool
-	logDriverErrSkip   bool
-	wrapResult         bool
-	minimumLogLevel    Level
-	durationUnit       DurationUnit
-	timeFormat         TimeFormat
-	uidGenerator       UIDGenerator
-	includeStartTime   bool
-	preparerLevel      Level
-	queryerLevel       Level
-	execerLevel        Level
+	errorFieldname           string
+	durationFieldname        string
+	timeFieldname            string
+	startTimeFieldname       string
+	sqlQueryFieldname        string
+	sqlArgsFieldname         string
+	stmtIDFieldname          string
+	connIDFieldname          string
+	txIDFieldname            string
+	sqlQueryAsMsg            bool
+	logArgs                  bool
+	logDriverErrSkip         bool
+	wrapResult               bool
+	minimumLogLevel          Level
+	durationUnit             DurationUnit
+	timeFormat               TimeFormat
+	uidGenerator             UIDGenerator
+	includeStartTime         bool
+	preparerLevel            Level
+	queryerLevel             Level
+	execerLevel              Level
+	slowQueryThreshold       time.Duration
+	slowQueryLevel           Level
+	slowQueryFieldname       string
+	argRedactors             []ArgRedactor
+	sqlDialect               SQLDialect
+	maskSQLLiterals          bool
+	queryFingerprint         bool
+	fingerprintFieldname     string
+	fingerprintHashFieldname string
+	sampler                  Sampler
+	sampledEvents            *sampledEvents
+	sampledSummaryInterval   time.Duration
+	asyncBufferSize          int
+	asyncDropPolicy          DropPolicy
+	rowsCloseLevel           Level
+	watchdogThreshold        time.Duration
+	watchdogInterval         time.Duration
+	leakDetection            bool
+	leakMaxLifetime          time.Duration
+	sqlCommenters            []CommentExtractor
+	contextFields            []func(ctx context.Context) map[string]interface{}
+	levelRules               []Rule
+	interceptors             []Interceptor
 }
 
 // setDefaultOptions called first time before Log() called (see: OpenDriver()).
@@ -56,6 +80,29 @@ func setDefaultOptions(opt *options) {
 	opt.preparerLevel = LevelInfo
 	opt.queryerLevel = LevelInfo
 	opt.execerLevel = LevelInfo
+	opt.slowQueryThreshold = 0
+	opt.slowQueryLevel = LevelInfo
+	opt.slowQueryFieldname = "slow"
+	opt.argRedactors = nil
+	opt.sqlDialect = SQLDialectGeneric
+	opt.maskSQLLiterals = false
+	opt.queryFingerprint = false
+	opt.fingerprintFieldname = "query_fingerprint"
+	opt.fingerprintHashFieldname = "query_hash"
+	opt.sampler = nil
+	opt.sampledEvents = nil
+	opt.sampledSummaryInterval = time.Minute
+	opt.asyncBufferSize = 0
+	opt.asyncDropPolicy = DropPolicyBlock
+	opt.rowsCloseLevel = LevelTrace
+	opt.watchdogThreshold = 0
+	opt.watchdogInterval = 0
+	opt.leakDetection = false
+	opt.leakMaxLifetime = 0
+	opt.sqlCommenters = nil
+	opt.contextFields = nil
+	opt.levelRules = nil
+	opt.interceptors = nil
 }
 
 // DurationUnit is total time spent on an actual driver function call calculated by time.Since(start).
@@ -117,7 +164,8 @@ func (tf TimeFormat) format(logTime time.Time) interface{} {
 // UIDGenerator is an interface to generate unique ID for context call (connection, statement, transaction).
 // The point of having unique id per context call is to easily track and analyze logs.
 //
-// Note: no possible way to track id when statement Execer(Context),Queryer(Context) called from under db.Tx.
+// Any call under db.Tx (Execer(Context), Queryer(Context), Preparer(Context), statement, rows, result)
+// will include transaction id until Commit/Rollback.
 type UIDGenerator interface {
 	UniqueID() string
 }
@@ -229,7 +277,7 @@ func WithSQLArgsFieldname(name string) Option {
 
 // WithMinimumLevel set minimum level to be logged. Logger will always log level >= minimum level.
 //
-// Options: LevelTrace < LevelDebug < LevelInfo < LevelError
+// Options: LevelTrace < LevelDebug < LevelInfo < LevelWarn < LevelError
 //
 // Default: LevelDebug
 func WithMinimumLevel(lvl Level) Option {
@@ -246,7 +294,7 @@ func WithMinimumLevel(lvl Level) Option {
 //
 // When set to false, any SQL and result/rows argument on Queryer(Context) and Execer(Context) will not logged.
 //
-// When set to true, argument type string and []byte will subject to trim on parseArgs() log output.
+// When set to true, argument type string and []byte longer than 64 bytes will be truncated in log output.
 //
 // Default: true
 func WithLogArguments(flag bool) Option {
@@ -368,6 +416,8 @@ func WithStartTimeFieldname(name string) Option {
 
 // WithPreparerLevel set default level of Prepare(Context) method calls.
 //
+// Deprecated: use WithLevelRules() with Rule Ops instead, it is applied on top of this default level.
+//
 // Default: LevelInfo
 func WithPreparerLevel(lvl Level) Option {
 	return func(opt *options) {
@@ -377,6 +427,8 @@ func WithPreparerLevel(lvl Level) Option {
 
 // WithQueryerLevel set default level of Query(Context) method calls.
 //
+// Deprecated: use WithLevelRules() with Rule Ops instead, it is applied on top of this default level.
+//
 // Default: LevelInfo
 func WithQueryerLevel(lvl Level) Option {
 	return func(opt *options) {
@@ -386,9 +438,290 @@ func WithQueryerLevel(lvl Level) Option {
 
 // WithExecerLevel set default level of Exec(Context) method calls.
 //
+// Deprecated: use WithLevelRules() with Rule Ops instead, it is applied on top of this default level.
+//
 // Default: LevelInfo
 func WithExecerLevel(lvl Level) Option {
 	return func(opt *options) {
 		opt.execerLevel = lvl
 	}
 }
+
+// WithRowsCloseLevel set default level of rows Close() method calls,
+// which include rows summary (row count, fetch time, first row latency, and open time).
+//
+// Set it to the same level as WithQueryerLevel to see rows summary without noisy per-row RowsNext log.
+//
+// Default: LevelTrace
+func WithRowsCloseLevel(lvl Level) Option {
+	return func(opt *options) {
+		opt.rowsCloseLevel = lvl
+	}
+}
+
+// WithSlowQueryThreshold set duration threshold of slow query and its escalated level.
+//
+// Any Exec*, Query*, Stmt*, Prepare*, Commit and Rollback call which took longer than given duration
+// will be logged at given level (if its normal level is lower) with slow query field set to true,
+// even when its normal level is below minimum level.
+//
+// Zero or negative duration disable slow query detection.
+//
+// Default: 0 (disabled)
+func WithSlowQueryThreshold(d time.Duration, lvl Level) Option {
+	return func(opt *options) {
+		if lvl > LevelError || lvl < LevelTrace {
+			return
+		}
+
+		opt.slowQueryThreshold = d
+		opt.slowQueryLevel = lvl
+	}
+}
+
+// WithSlowQueryFieldname to customize slow query flag fieldname on log output.
+//
+// Default: "slow"
+func WithSlowQueryFieldname(name string) Option {
+	return func(opt *options) {
+		opt.slowQueryFieldname = name
+	}
+}
+
+// WithArgRedactor add ArgRedactor to replace sensitive SQL query argument and rows destination value
+// before it reach Logger. Multiple redactors will be applied in given order.
+//
+// Built-in redactors: RedactNamedArgs, RedactArgsMatching, RedactArgsAt.
+//
+// Default: none
+func WithArgRedactor(redactors ...ArgRedactor) Option {
+	return func(opt *options) {
+		opt.argRedactors = append(opt.argRedactors, redactors...)
+	}
+}
+
+// WithSQLDialect set SQL syntax variant of wrapped driver, used when tokenizing SQL query.
+//
+// Options: SQLDialectGeneric | SQLDialectMySQL | SQLDialectPostgres | SQLDialectSQLite
+//
+// Default: SQLDialectGeneric
+func WithSQLDialect(dialect SQLDialect) Option {
+	return func(opt *options) {
+		if dialect > SQLDialectSQLite {
+			return
+		}
+
+		opt.sqlDialect = dialect
+	}
+}
+
+// WithMaskSQLLiterals set flag to replace string and numeric literal in logged SQL query with "?".
+//
+// Use this to prevent data leak from query with inline literal, even when WithLogArguments(false).
+// See WithSQLDialect() to set dialect-specific quoting and escape rules.
+//
+// Default: false
+func WithMaskSQLLiterals(flag bool) Option {
+	return func(opt *options) {
+		opt.maskSQLLiterals = flag
+	}
+}
+
+// WithQueryFingerprint set flag to include query fingerprint and its hash on every log with SQL query.
+//
+// Fingerprint is normalized SQL query (see: FingerprintSQL) which can be used to group similar queries,
+// hash is stable short hash of the fingerprint (see: FingerprintHash).
+//
+// Default: false
+func WithQueryFingerprint(flag bool) Option {
+	return func(opt *options) {
+		opt.queryFingerprint = flag
+	}
+}
+
+// WithQueryFingerprintFieldname to customize query fingerprint fieldname on log output.
+//
+// Default: "query_fingerprint"
+func WithQueryFingerprintFieldname(name string) Option {
+	return func(opt *options) {
+		opt.fingerprintFieldname = name
+	}
+}
+
+// WithQueryHashFieldname to customize query fingerprint hash fieldname on log output.
+//
+// Default: "query_hash"
+func WithQueryHashFieldname(name string) Option {
+	return func(opt *options) {
+		opt.fingerprintHashFieldname = name
+	}
+}
+
+// WithSampler set Sampler to decide which log events delivered to Logger.
+//
+// Sampled out events are counted per query fingerprint (or message if no query) and summarized
+// in a single "Sampled" log once every summary interval (see: WithSampledSummaryInterval).
+// The summary is delivered on the next log event after the interval elapsed.
+//
+// Built-in samplers: NewRatioSampler, NewFirstNSampler, NewErrorAndSlowSampler.
+//
+// Default: nil (no sampling)
+func WithSampler(sampler Sampler) Option {
+	return func(opt *options) {
+		opt.sampler = sampler
+		opt.sampledEvents = newSampledEvents()
+	}
+}
+
+// WithSampledSummaryInterval set interval of sampled out events summary log.
+//
+// Default: 1 minute
+func WithSampledSummaryInterval(d time.Duration) Option {
+	return func(opt *options) {
+		if d <= 0 {
+			return
+		}
+
+		opt.sampledSummaryInterval = d
+	}
+}
+
+// WithAsyncLogger deliver log to Logger from a background worker via bounded queue of given size,
+// so slow Logger does not add latency to database call.
+//
+// Use NewController() to Flush() and Close() the worker on shutdown, otherwise queued logs may be lost.
+//
+// Options: DropPolicyBlock | DropPolicyDropNewest | DropPolicyDropOldest
+//
+// Default: 0 (synchronous)
+func WithAsyncLogger(bufferSize int, policy DropPolicy) Option {
+	return func(opt *options) {
+		if bufferSize < 0 || policy > DropPolicyDropOldest {
+			return
+		}
+
+		opt.asyncBufferSize = bufferSize
+		opt.asyncDropPolicy = policy
+	}
+}
+
+// WithWatchdog enable background watchdog which log in-flight driver call (Connect, Begin(Tx), Prepare(Context),
+// Ping, Exec(Context), Query(Context), statement Exec(Context)/Query(Context), Commit, Rollback)
+// and open transaction which is running longer than given threshold, repeated every given interval until finished.
+//
+// The log include elapsed time, related IDs and query (if any), so hanging call is visible before it returns.
+// Use NewController() to stop the watchdog on shutdown.
+//
+// Zero or negative threshold disable the watchdog. Zero or negative interval will use the threshold.
+//
+// Default: 0 (disabled)
+func WithWatchdog(threshold, interval time.Duration) Option {
+	return func(opt *options) {
+		if interval <= 0 {
+			interval = threshold
+		}
+
+		opt.watchdogThreshold = threshold
+		opt.watchdogInterval = interval
+	}
+}
+
+// WithLeakDetection flag to log rows which is garbage collected without Close(),
+// and rows, statement or transaction still open after given max lifetime.
+//
+// The error log include query, related IDs and stack trace captured when it created.
+// Capturing stack trace is costly, it is intended for development and debugging.
+//
+// Statement and transaction are never garbage collected while database/sql keep its connection in the pool,
+// so their leak is only detected by max lifetime. Zero or negative max lifetime will only detect rows leak.
+//
+// Default: false
+func WithLeakDetection(flag bool, maxLifetime time.Duration) Option {
+	return func(opt *options) {
+		opt.leakDetection = flag
+		opt.leakMaxLifetime = maxLifetime
+	}
+}
+
+// WithSQLCommenter append sqlcommenter-style comment /*key='value',...*/ to query sent by
+// ExecContext, QueryContext and PrepareContext, with values from given context extractors.
+//
+// It can be used to map query in database activity (e.g: pg_stat_activity) back to application request.
+// Logged query is the final query sent to the driver. Query which already has a comment is sent as is.
+// Call it multiple times will add more extractors.
+//
+// Default: none
+func WithSQLCommenter(extractors ...CommentExtractor) Option {
+	return func(opt *options) {
+		opt.sqlCommenters = append(opt.sqlCommenters, extractors...)
+	}
+}
+
+// WithContextFields add log data from context of every log (e.g: request id, user id, tenant id),
+// so it is available for every Logger implementation.
+//
+// Context of Commit, Rollback, rows, statement and result log is the context of originating
+// Begin(Tx), Query(Context), Prepare(Context) and Exec(Context) call. Connection Close log has
+// context.Background(), because the connection outlives the request which opened it.
+// Returned fields will not override sqldblogger fields. Call it multiple times will add more extractors.
+//
+// Default: none
+func WithContextFields(fn func(ctx context.Context) map[string]interface{}) Option {
+	return func(opt *options) {
+		if fn == nil {
+			return
+		}
+
+		opt.contextFields = append(opt.contextFields, fn)
+	}
+}
+
+// WithLevelRules assign log level by rules, first matching rule (see: Rule) set the level of a log event.
+// Log event without matching rule use its default level.
+// Failed call (LevelError) is only matched by rule with Error criteria, so its error is not hidden by broader rule.
+//
+// Rules are applied before slow query escalation (see: WithSlowQueryThreshold) and minimum level check,
+// so log data is collected for every call when any rule is set.
+// Query matched by rule is the logged query (see: WithMaskSQLLiterals). Call it multiple times will add more rules.
+//
+// Example:
+//
+//	WithLevelRules(
+//		Rule{Ops: []string{"Ping"}, Level: LevelTrace},
+//		Rule{Error: ErrorIs(driver.ErrBadConn), Level: LevelWarn},
+//		Rule{Ops: []string{"QueryContext", "StmtQueryContext"}, MinDuration: time.Second, Level: LevelWarn},
+//		Rule{Query: regexp.MustCompile(`^SELECT 1$`), Level: LevelTrace},
+//	)
+//
+// Default: none
+func WithLevelRules(rules ...Rule) Option {
+	return func(opt *options) {
+		for _, r := range rules {
+			if r.Level > LevelError {
+				continue
+			}
+
+			opt.levelRules = append(opt.levelRules, r)
+		}
+	}
+}
+
+// WithInterceptors wrap every driver call (except NamedValueChecker and ColumnConverter) with given interceptors,
+// first interceptor is the outermost. Interceptor can rewrite the query or arguments, short-circuit the call with an error,
+// or attach fields to the call log (see: Interceptor and Call).
+//
+// Logging is done after the interceptors chain finished, so logged query, arguments and error are the final one.
+// Call it multiple times will add more interceptors.
+//
+// Default: none
+func WithInterceptors(interceptors ...Interceptor) Option {
+	return func(opt *options) {
+		for _, i := range interceptors {
+			if i == nil {
+				continue
+			}
+
+			opt.interceptors = append(opt.interceptors, i)
+		}
+	}
+}
@@ -201,16 +201,7 @@ func TestWithUIDGenerator(t *testing.T) {
 		bl := &bufferTestLogger{}
 		l := &logger{opt: cfg, logger: bl}
 
-		l.log(
-			context.TODO(),
-			LevelInfo,
-			"msg",
-			time.Now(),
-			nil,
-			testLogger.withUID(cfg.stmtIDFieldname, l.opt.uidGenerator.UniqueID()),
-			testLogger.withQuery("query"),
-			testLogger.withArgs([]driver.Value{}),
-		)
+		l.logCall(&Call{Ctx: context.TODO(), Op: "msg", Start: time.Now(), StmtID: l.opt.uidGenerator.UniqueID(), Query: "query", Args: valuesToNamedValues([]driver.Value{})}, LevelInfo, nil)
 
 		var content bufLog
 		err := json.Unmarshal(bl.Bytes(), &content)
@@ -241,16 +232,7 @@ func TestWithIncludeStartTime(t *testing.T) {
 		bl := &bufferTestLogger{}
 		l := &logger{opt: cfg, logger: bl}
 		start := time.Now()
-		l.log(
-			context.TODO(),
-			LevelInfo,
-			"msg",
-			start,
-			nil,
-			testLogger.withUID(cfg.stmtIDFieldname, l.opt.uidGenerator.UniqueID()),
-			testLogger.withQuery("query"),
-			testLogger.withArgs([]driver.Value{}),
-		)
+		l.logCall(&Call{Ctx: context.TODO(), Op: "msg", Start: start, StmtID: l.opt.uidGenerator.UniqueID(), Query: "query", Args: valuesToNamedValues([]driver.Value{})}, LevelInfo, nil)
 
 		var content bufLog
 		err := json.Unmarshal(bl.Bytes(), &content)
@@ -312,6 +294,164 @@ func TestWithExecerLevel(t *testing.T) {
 	})
 }
 
+func TestWithRowsCloseLevel(t *testing.T) {
+	cfg := &options{}
+	setDefaultOptions(cfg)
+	assert.Equal(t, LevelTrace, cfg.rowsCloseLevel)
+
+	WithRowsCloseLevel(LevelInfo)(cfg)
+	assert.Equal(t, LevelInfo, cfg.rowsCloseLevel)
+}
+
+func TestWithSlowQueryThreshold(t *testing.T) {
+	t.Run("Default value", func(t *testing.T) {
+		cfg := &options{}
+		setDefaultOptions(cfg)
+
+		assert.Equal(t, time.Duration(0), cfg.slowQueryThreshold)
+		assert.Equal(t, "slow", cfg.slowQueryFieldname)
+	})
+
+	t.Run("Custom value", func(t *testing.T) {
+		cfg := &options{}
+		setDefaultOptions(cfg)
+		WithSlowQueryThreshold(500*time.Millisecond, LevelError)(cfg)
+		WithSlowQueryFieldname("slow_query")(cfg)
+
+		assert.Equal(t, 500*time.Millisecond, cfg.slowQueryThreshold)
+		assert.Equal(t, LevelError, cfg.slowQueryLevel)
+		assert.Equal(t, "slow_query", cfg.slowQueryFieldname)
+	})
+
+	t.Run("Invalid level", func(t *testing.T) {
+		cfg := &options{}
+		setDefaultOptions(cfg)
+		WithSlowQueryThreshold(time.Second, Level(99))(cfg)
+
+		assert.Equal(t, time.Duration(0), cfg.slowQueryThreshold)
+	})
+}
+
+func TestWithSQLDialect(t *testing.T) {
+	cfg := &options{}
+	setDefaultOptions(cfg)
+	assert.Equal(t, SQLDialectGeneric, cfg.sqlDialect)
+
+	WithSQLDialect(SQLDialectPostgres)(cfg)
+	assert.Equal(t, SQLDialectPostgres, cfg.sqlDialect)
+
+	WithSQLDialect(SQLDialect(99))(cfg)
+	assert.Equal(t, SQLDialectPostgres, cfg.sqlDialect)
+}
+
+func TestWithMaskSQLLiterals(t *testing.T) {
+	cfg := &options{}
+	setDefaultOptions(cfg)
+	assert.False(t, cfg.maskSQLLiterals)
+
+	WithMaskSQLLiterals(true)(cfg)
+	assert.True(t, cfg.maskSQLLiterals)
+}
+
+func TestWithQueryFingerprint(t *testing.T) {
+	cfg := &options{}
+	setDefaultOptions(cfg)
+	assert.False(t, cfg.queryFingerprint)
+	assert.Equal(t, "query_fingerprint", cfg.fingerprintFieldname)
+	assert.Equal(t, "query_hash", cfg.fingerprintHashFieldname)
+
+	WithQueryFingerprint(true)(cfg)
+	WithQueryFingerprintFieldname("fp")(cfg)
+	WithQueryHashFieldname("fp_hash")(cfg)
+	assert.True(t, cfg.queryFingerprint)
+	assert.Equal(t, "fp", cfg.fingerprintFieldname)
+	assert.Equal(t, "fp_hash", cfg.fingerprintHashFieldname)
+}
+
+func TestWithSampler(t *testing.T) {
+	cfg := &options{}
+	setDefaultOptions(cfg)
+	assert.Nil(t, cfg.sampler)
+	assert.Equal(t, time.Minute, cfg.sampledSummaryInterval)
+
+	sampler := NewRatioSampler(0.5)
+	WithSampler(sampler)(cfg)
+	WithSampledSummaryInterval(time.Second)(cfg)
+	WithSampledSummaryInterval(0)(cfg)
+	assert.NotNil(t, cfg.sampler)
+	assert.NotNil(t, cfg.sampledEvents)
+	assert.Equal(t, time.Second, cfg.sampledSummaryInterval)
+}
+
+func TestWithAsyncLogger(t *testing.T) {
+	cfg := &options{}
+	setDefaultOptions(cfg)
+	assert.Equal(t, 0, cfg.asyncBufferSize)
+
+	WithAsyncLogger(100, DropPolicyDropOldest)(cfg)
+	assert.Equal(t, 100, cfg.asyncBufferSize)
+	assert.Equal(t, DropPolicyDropOldest, cfg.asyncDropPolicy)
+
+	WithAsyncLogger(10, DropPolicy(99))(cfg)
+	assert.Equal(t, 100, cfg.asyncBufferSize)
+}
+
+func TestWithWatchdog(t *testing.T) {
+	cfg := &options{}
+	setDefaultOptions(cfg)
+	assert.Equal(t, time.Duration(0), cfg.watchdogThreshold)
+
+	WithWatchdog(time.Minute, 0)(cfg)
+	assert.Equal(t, time.Minute, cfg.watchdogThreshold)
+	assert.Equal(t, time.Minute, cfg.watchdogInterval)
+
+	WithWatchdog(time.Minute, time.Second)(cfg)
+	assert.Equal(t, time.Second, cfg.watchdogInterval)
+}
+
+func TestWithLeakDetection(t *testing.T) {
+	cfg := &options{}
+	setDefaultOptions(cfg)
+	assert.False(t, cfg.leakDetection)
+
+	WithLeakDetection(true, time.Minute)(cfg)
+	assert.True(t, cfg.leakDetection)
+	assert.Equal(t, time.Minute, cfg.leakMaxLifetime)
+}
+
+func TestWithSQLCommenter(t *testing.T) {
+	cfg := &options{}
+	setDefaultOptions(cfg)
+	assert.Empty(t, cfg.sqlCommenters)
+
+	WithSQLCommenter(CommentFromContextValue("route", "route"))(cfg)
+	WithSQLCommenter(CommentFromContextValue("request_id", "request_id"))(cfg)
+	assert.Len(t, cfg.sqlCommenters, 2)
+}
+
+func TestWithContextFields(t *testing.T) {
+	cfg := &options{}
+	setDefaultOptions(cfg)
+	assert.Empty(t, cfg.contextFields)
+
+	WithContextFields(nil)(cfg)
+	assert.Empty(t, cfg.contextFields)
+
+	WithContextFields(ctxFieldsTest)(cfg)
+	assert.Len(t, cfg.contextFields, 1)
+}
+
+func TestWithInterceptors(t *testing.T) {
+	cfg := &options{}
+	setDefaultOptions(cfg)
+	assert.Empty(t, cfg.interceptors)
+
+	noop := func(_ *Call, next func() error) error { return next() }
+	WithInterceptors(noop, nil)(cfg)
+	WithInterceptors(noop)(cfg)
+	assert.Len(t, cfg.interceptors, 2)
+}
+
 var uidBtest = newDefaultUIDDGenerator()
 
 func BenchmarkUniqueID(b *testing.B) {
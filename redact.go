@@ -0,0 +1,74 @@
+package sqldblogger
+
+import (
+	"database/sql/driver"
+	"regexp"
+	"strings"
+)
+
+// RedactedValue is replacement value returned by built-in ArgRedactor.
+const RedactedValue = "[REDACTED]"
+
+// ArgRedactor is a hook to replace SQL query argument (and rows destination) value before it reach Logger.
+//
+// Ordinal is argument position starting from 1, name is named parameter name if any
+// (for rows destination, name is the column name).
+// Return given value as is to keep it.
+type ArgRedactor func(query string, ordinal int, name string, value driver.Value) driver.Value
+
+// RedactNamedArgs redact argument by its named parameter name (case-insensitive).
+func RedactNamedArgs(names ...string) ArgRedactor {
+	return func(_ string, _ int, name string, value driver.Value) driver.Value {
+		if name == "" {
+			return value
+		}
+
+		for _, n := range names {
+			if strings.EqualFold(n, name) {
+				return RedactedValue
+			}
+		}
+
+		return value
+	}
+}
+
+// RedactArgsMatching redact string or []byte argument which value match given pattern.
+func RedactArgsMatching(pattern *regexp.Regexp) ArgRedactor {
+	return func(_ string, _ int, _ string, value driver.Value) driver.Value {
+		switch v := value.(type) {
+		case string:
+			if pattern.MatchString(v) {
+				return RedactedValue
+			}
+		case []byte:
+			if pattern.Match(v) {
+				return RedactedValue
+			}
+		}
+
+		return value
+	}
+}
+
+// RedactArgsAt redact argument at given ordinal positions (starting from 1) when query match given pattern.
+func RedactArgsAt(queryPattern *regexp.Regexp, ordinals ...int) ArgRedactor {
+	return func(query string, ordinal int, _ string, value driver.Value) driver.Value {
+		for _, o := range ordinals {
+			if o == ordinal && queryPattern.MatchString(query) {
+				return RedactedValue
+			}
+		}
+
+		return value
+	}
+}
+
+// redactArg apply all ArgRedactor option in order to given argument value.
+func redactArg(opt *options, query string, ordinal int, name string, value driver.Value) driver.Value {
+	for _, redact := range opt.argRedactors {
+		value = redact(query, ordinal, name, value)
+	}
+
+	return value
+}
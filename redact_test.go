@@ -0,0 +1,62 @@
+package sqldblogger
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactNamedArgs(t *testing.T) {
+	redact := RedactNamedArgs("password", "Token")
+
+	assert.Equal(t, RedactedValue, redact("query", 1, "password", "secret"))
+	assert.Equal(t, RedactedValue, redact("query", 2, "token", "secret"))
+	assert.Equal(t, "john", redact("query", 3, "username", "john"))
+	assert.Equal(t, "secret", redact("query", 4, "", "secret"))
+}
+
+func TestRedactArgsMatching(t *testing.T) {
+	redact := RedactArgsMatching(regexp.MustCompile(`^[^@\s]+@[^@\s]+$`))
+
+	assert.Equal(t, RedactedValue, redact("query", 1, "", "john@example.com"))
+	assert.Equal(t, RedactedValue, redact("query", 1, "", []byte("john@example.com")))
+	assert.Equal(t, "john", redact("query", 1, "", "john"))
+	assert.Equal(t, 1, redact("query", 1, "", 1))
+}
+
+func TestRedactArgsAt(t *testing.T) {
+	redact := RedactArgsAt(regexp.MustCompile(`(?i)^INSERT INTO users`), 2)
+
+	assert.Equal(t, RedactedValue, redact("INSERT INTO users (name, password) VALUES (?, ?)", 2, "", "secret"))
+	assert.Equal(t, "john", redact("INSERT INTO users (name, password) VALUES (?, ?)", 1, "", "john"))
+	assert.Equal(t, "secret", redact("INSERT INTO posts (title, body) VALUES (?, ?)", 2, "", "secret"))
+}
+
+func TestWithArgRedactor(t *testing.T) {
+	cfg := &options{}
+	setDefaultOptions(cfg)
+	WithArgRedactor(
+		RedactNamedArgs("password"),
+		RedactArgsAt(regexp.MustCompile(`users`), 1),
+	)(cfg)
+	assert.Len(t, cfg.argRedactors, 2)
+
+	bl := &bufferTestLogger{}
+	l := &logger{opt: cfg, logger: bl}
+	q := "UPDATE users SET email = ?, password = :password WHERE id = ?"
+	l.logCall(&Call{Ctx: context.TODO(), Op: "msg", Start: time.Now(), Query: q, Args: []driver.NamedValue{
+		{Ordinal: 1, Value: "john@example.com"},
+		{Ordinal: 2, Name: "password", Value: "secret"},
+		{Ordinal: 3, Value: 9},
+	}}, LevelInfo, nil)
+
+	var content bufLog
+	err := json.Unmarshal(bl.Bytes(), &content)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{RedactedValue, RedactedValue, float64(9)}, content.Data[cfg.sqlArgsFieldname])
+}
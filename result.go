@@ -3,53 +3,65 @@ package sqldblogger
 import (
 	"context"
 	"database/sql/driver"
-	"time"
 )
 
 // result is a wrapper for driver.Result.
 type result struct {
 	driver.Result
+	// ctx is context of Exec(Context) call, used to log result call.
+	ctx    context.Context
 	logger *logger
 	connID string
+	txID   string
 	stmtID string
 	query  string
-	args   []driver.Value
+	args   []driver.NamedValue
 }
 
 // LastInsertId implement driver.Result
 func (r *result) LastInsertId() (int64, error) {
-	lvl, start := LevelTrace, time.Now()
-	id, err := r.Result.LastInsertId()
+	return r.call("ResultLastInsertId")
+}
+
+// RowsAffected implement driver.Result
+func (r *result) RowsAffected() (int64, error) {
+	return r.call("ResultRowsAffected")
+}
+
+// call intercept and log given result call, it return the call number result.
+func (r *result) call(op string) (int64, error) {
+	lvl := LevelTrace
+	call := r.logger.newCall(r.ctx, op, r)
+	defer call.release()
 
+	call.Query, call.Args = r.query, r.args
+	call.ConnID, call.StmtID, call.TxID = r.connID, r.stmtID, r.txID
+
+	err := r.logger.intercept(call)
 	if err != nil {
 		lvl = LevelError
 	}
 
-	r.logger.log(context.Background(), lvl, "ResultLastInsertId", start, err, r.logData()...)
+	r.logger.logCall(call, lvl, r)
 
-	return id, err
+	return call.num, err
 }
 
-// RowsAffected implement driver.Result
-func (r *result) RowsAffected() (int64, error) {
-	lvl, start := LevelTrace, time.Now()
-	num, err := r.Result.RowsAffected()
-
-	if err != nil {
-		lvl = LevelError
+// invoke implements invoker, it do the actual driver call.
+func (r *result) invoke(call *Call) (err error) {
+	if call.Op == "ResultLastInsertId" {
+		call.num, err = r.Result.LastInsertId()
+		return err
 	}
 
-	r.logger.log(context.Background(), lvl, "ResultRowsAffected", start, err, r.logData()...)
+	call.num, err = r.Result.RowsAffected()
 
-	return num, err
+	return err
 }
 
-// logData default log data for result.
-func (r *result) logData() []dataFunc {
-	return []dataFunc{
-		r.logger.withUID(r.logger.opt.connIDFieldname, r.connID),
-		r.logger.withUID(r.logger.opt.stmtIDFieldname, r.stmtID),
-		r.logger.withQuery(r.query),
-		r.logger.withArgs(r.args),
+// eventFields implements eventFielder, it set rows affected of RowsAffected() log.
+func (r *result) eventFields(e *Event, call *Call) {
+	if call.Op == "ResultRowsAffected" && call.Err == nil {
+		e.RowsAffected = call.num
 	}
 }
@@ -8,7 +8,8 @@ import (
 	"time"
 )
 
-// rows is a wrapper which implements:
+// rows is a wrapper which log rows summary (row count, fetch time, first row latency, open time) on Close()
+// and implements:
 // - driver.Rows
 // - driver.RowsNextResultSet
 // - driver.RowsColumnTypeScanType
@@ -18,11 +19,23 @@ import (
 // - driver.RowsColumnTypePrecisionScale
 type rows struct {
 	driver.Rows
+	// ctx is context of Query(Context) call, used to log rows call.
+	ctx    context.Context
 	logger *logger
 	connID string
+	txID   string
 	stmtID string
 	query  string
-	args   []driver.Value
+	args   []driver.NamedValue
+	// openedAt is time when rows returned from driver, used for rows summary on Close().
+	openedAt time.Time
+	// count is total row fetched by Next(), fetchDuration is total time spent on Next(),
+	// firstRow is time from openedAt until first row fetched.
+	count         int64
+	fetchDuration time.Duration
+	firstRow      time.Duration
+	// leak is leak detector tracker, nil if disabled (see: WithLeakDetection).
+	leak *leakTracker
 }
 
 // Columns implement driver.Rows
@@ -32,36 +45,38 @@ func (r *rows) Columns() []string {
 
 // Close implement driver.Rows
 func (r *rows) Close() error {
-	lvl, start := LevelTrace, time.Now()
-	err := r.Rows.Close()
+	r.leak.close()
 
+	lvl := r.logger.options().rowsCloseLevel
+	call := r.call("RowsClose")
+	defer call.release()
+
+	err := r.logger.intercept(call)
 	if err != nil {
 		lvl = LevelError
 	}
 
-	r.logger.log(context.Background(), lvl, "RowsClose", start, err, r.logData()...)
+	r.logger.logCall(call, lvl, r)
 
 	return err
 }
 
 // Next implement driver.Rows
 func (r *rows) Next(dest []driver.Value) error {
-	logs := r.logData()
+	lvl := LevelTrace
+	call := r.call("RowsNext")
+	defer call.release()
 
-	// dest contain value from database.
-	// If query arg not logged, dest arg here will also not logged.
-	if r.logger.opt.logArgs {
-		logs = append(logs, r.logger.withKeyArgs("rows_dest", dest))
-	}
+	call.dest = dest
 
-	lvl, start := LevelTrace, time.Now()
-	err := r.Rows.Next(dest)
+	err := r.logger.intercept(call)
+	r.fetched(call.Start, err)
 
 	if err != nil && err != io.EOF {
 		lvl = LevelError
 	}
 
-	r.logger.log(context.Background(), lvl, "RowsNext", start, err, logs...)
+	r.logger.logCall(call, lvl, r)
 
 	return err
 }
@@ -77,19 +92,20 @@ func (r *rows) HasNextResultSet() bool {
 
 // NextResultSet implement driver.RowsNextResultSet
 func (r *rows) NextResultSet() error {
-	rs, ok := r.Rows.(driver.RowsNextResultSet)
-	if !ok {
+	if _, ok := r.Rows.(driver.RowsNextResultSet); !ok {
 		return io.EOF
 	}
 
-	lvl, start := LevelTrace, time.Now()
-	err := rs.NextResultSet()
+	lvl := LevelTrace
+	call := r.call("RowsNextResultSet")
+	defer call.release()
 
+	err := r.logger.intercept(call)
 	if err != nil && err != io.EOF {
 		lvl = LevelError
 	}
 
-	r.logger.log(context.Background(), lvl, "RowsNextResultSet", start, err, r.logData()...)
+	r.logger.logCall(call, lvl, r)
 
 	return err
 }
@@ -139,12 +155,94 @@ func (r *rows) ColumnTypePrecisionScale(index int) (precision, scale int64, ok b
 	return 0, 0, false
 }
 
-// logData default log data for rows.
-func (r *rows) logData() []dataFunc {
-	return []dataFunc{
-		r.logger.withUID(r.logger.opt.connIDFieldname, r.connID),
-		r.logger.withUID(r.logger.opt.stmtIDFieldname, r.stmtID),
-		r.logger.withQuery(r.query),
-		r.logger.withArgs(r.args),
+// withDest log rows destination value as arguments with column name, so it can be redacted by ArgRedactor.
+// It is called after Rows.Next() fill the destination.
+func (r *rows) withDest(e *Event, dest []driver.Value) {
+	if len(dest) == 0 {
+		return
+	}
+
+	var columns []string
+
+	if len(e.opt.argRedactors) > 0 {
+		columns = r.Rows.Columns()
+	}
+
+	values := make([]interface{}, len(dest))
+
+	for i, v := range dest {
+		var name string
+
+		if i < len(columns) {
+			name = columns[i]
+		}
+
+		values[i] = truncateArg(redactArg(e.opt, r.query, i+1, name, v))
+	}
+
+	e.fields()["rows_dest"] = values
+}
+
+// fetched record rows summary after Next() call.
+func (r *rows) fetched(start time.Time, err error) {
+	now := time.Now()
+	r.fetchDuration += now.Sub(start)
+
+	if err != nil {
+		return
+	}
+
+	if r.count == 0 {
+		r.firstRow = now.Sub(r.openedAt)
+	}
+
+	r.count++
+}
+
+// eventFields implements eventFielder, it add rows summary to Close() log,
+// and rows destination to Next() log if query arguments logged.
+func (r *rows) eventFields(e *Event, call *Call) {
+	switch call.Op {
+	case "RowsClose":
+		fields := e.fields()
+		fields["rows_count"] = r.count
+		fields["rows_fetch_duration"] = r.fetchDuration
+		fields["rows_open_duration"] = call.Start.Sub(r.openedAt)
+
+		if r.count > 0 {
+			fields["rows_first_row_duration"] = r.firstRow
+		}
+	case "RowsNext":
+		// dest contain value from database.
+		// If query arg not logged, dest arg here will also not logged.
+		if e.opt.logArgs {
+			r.withDest(e, call.dest)
+		}
 	}
 }
+
+// call get interceptor call descriptor for rows call.
+func (r *rows) call(op string) *Call {
+	call := r.logger.newCall(r.ctx, op, r)
+	call.Query, call.Args = r.query, r.args
+	call.ConnID, call.StmtID, call.TxID = r.connID, r.stmtID, r.txID
+
+	return call
+}
+
+// invoke implements invoker, it do the actual driver call.
+func (r *rows) invoke(call *Call) error {
+	switch call.Op {
+	case "RowsClose":
+		return r.Rows.Close()
+	case "RowsNext":
+		return r.Rows.Next(call.dest)
+	default:
+		return r.Rows.(driver.RowsNextResultSet).NextResultSet()
+	}
+}
+
+// leakData implements leakObject.
+func (r *rows) leakData(t *leakTracker) {
+	t.connID, t.txID, t.stmtID, t.query, t.args = r.connID, r.txID, r.stmtID, r.query, r.args
+}
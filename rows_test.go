@@ -6,6 +6,7 @@ import (
 	"io"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -53,6 +54,49 @@ func TestRows_Close(t *testing.T) {
 	})
 }
 
+func TestRows_CloseSummary(t *testing.T) {
+	custOpt := *testOpts
+	WithRowsCloseLevel(LevelInfo)(&custOpt)
+	custLogger := *testLogger
+	custLogger.opt = &custOpt
+
+	fetchRowsMock := &rowsMock{}
+	fetchRowsMock.On("Next", mock.Anything).Return(nil).Twice()
+	fetchRowsMock.On("Next", mock.Anything).Return(io.EOF).Once()
+	fetchRowsMock.On("Close").Return(nil)
+	rs := &rows{Rows: fetchRowsMock, logger: &custLogger, connID: custLogger.opt.uidGenerator.UniqueID(), query: "SELECT 1", openedAt: time.Now()}
+
+	assert.NoError(t, rs.Next([]driver.Value{1}))
+	assert.NoError(t, rs.Next([]driver.Value{1}))
+	assert.Equal(t, io.EOF, rs.Next([]driver.Value{1}))
+	assert.NoError(t, rs.Close())
+
+	var output bufLog
+	err := json.Unmarshal(bufLogger.Bytes(), &output)
+	assert.NoError(t, err)
+	assert.Equal(t, "RowsClose", output.Message)
+	assert.Equal(t, LevelInfo.String(), output.Level)
+	assert.Equal(t, float64(2), output.Data["rows_count"])
+	assert.Contains(t, output.Data, "rows_fetch_duration")
+	assert.Contains(t, output.Data, "rows_first_row_duration")
+	assert.Contains(t, output.Data, "rows_open_duration")
+	bufLogger.Reset()
+
+	t.Run("Without Row", func(t *testing.T) {
+		emptyRowsMock := &rowsMock{}
+		emptyRowsMock.On("Close").Return(nil)
+		rs := &rows{Rows: emptyRowsMock, logger: &custLogger, query: "SELECT 1", openedAt: time.Now()}
+		assert.NoError(t, rs.Close())
+
+		var output bufLog
+		err := json.Unmarshal(bufLogger.Bytes(), &output)
+		assert.NoError(t, err)
+		assert.Equal(t, float64(0), output.Data["rows_count"])
+		assert.NotContains(t, output.Data, "rows_first_row_duration")
+		bufLogger.Reset()
+	})
+}
+
 func TestRows_Next(t *testing.T) {
 	t.Run("Error io.EOF", func(t *testing.T) {
 		rowsMock := &rowsMock{}
@@ -86,6 +130,25 @@ func TestRows_Next(t *testing.T) {
 		bufLogger.Reset()
 	})
 
+	t.Run("Redacted Dest Value By Column Name", func(t *testing.T) {
+		rowsMock := &rowsMock{}
+		rowsMock.On("Next", mock.Anything).Return(driver.ErrBadConn)
+		rowsMock.On("Columns").Return([]string{"id", "password"})
+		WithArgRedactor(RedactNamedArgs("password"))(testOpts)
+
+		rs := &rows{Rows: rowsMock, logger: testLogger, connID: testLogger.opt.uidGenerator.UniqueID(), query: "SELECT id, password FROM users"}
+
+		err := rs.Next([]driver.Value{1, "secret"})
+		assert.Error(t, err)
+
+		var output bufLog
+		err = json.Unmarshal(bufLogger.Bytes(), &output)
+		assert.NoError(t, err)
+		assert.Equal(t, []interface{}{float64(1), RedactedValue}, output.Data["rows_dest"])
+		bufLogger.Reset()
+		setDefaultOptions(testOpts)
+	})
+
 	t.Run("Error Non-io.EOF Without Dest Value", func(t *testing.T) {
 		rowsMock := &rowsMock{}
 		rowsMock.On("Next", mock.Anything).Return(driver.ErrBadConn)
@@ -0,0 +1,116 @@
+package sqldblogger
+
+import (
+	"errors"
+	"regexp"
+	"time"
+)
+
+// Rule assign log level to log events matching every rule criteria (see: WithLevelRules).
+// Empty criteria match any log event, except failed call (LevelError) which is only matched by rule with Error criteria,
+// so failed call is never logged below LevelError unless explicitly requested.
+type Rule struct {
+	// Ops match operation name, which is the log message (e.g: "QueryContext", "StmtExec", "RowsClose").
+	Ops []string
+	// Query match SQL query.
+	Query *regexp.Regexp
+	// Fingerprint match query fingerprint (see: FingerprintSQL).
+	Fingerprint string
+	// MinDuration match call which took at least given duration.
+	MinDuration time.Duration
+	// Error match driver error (including nil error), e.g: ErrorIs(io.EOF).
+	// It is required to match failed call, e.g: ErrorIs(sql.ErrNoRows) or AnyError().
+	Error func(err error) bool
+	// ConnID match connection id.
+	ConnID string
+	// Level is assigned level of matching log event.
+	Level Level
+}
+
+// ErrorIs create Rule error matcher which match error using errors.Is().
+func ErrorIs(target error) func(err error) bool {
+	return func(err error) bool {
+		return errors.Is(err, target)
+	}
+}
+
+// AnyError create Rule error matcher which match any non-nil error.
+func AnyError() func(err error) bool {
+	return func(err error) bool {
+		return err != nil
+	}
+}
+
+// ruleEvent is log event information matched by Rule.
+type ruleEvent struct {
+	op       string
+	query    string
+	connID   string
+	duration time.Duration
+	err      error
+	dialect  SQLDialect
+	// fingerprint is computed once on first Rule with Fingerprint criteria.
+	fingerprint *string
+}
+
+// match check if given event match every rule criteria.
+func (r Rule) match(e *ruleEvent) bool {
+	if len(r.Ops) > 0 && !containsString(r.Ops, e.op) {
+		return false
+	}
+
+	if r.ConnID != "" && r.ConnID != e.connID {
+		return false
+	}
+
+	if e.duration < r.MinDuration {
+		return false
+	}
+
+	if r.Error != nil && !r.Error(e.err) {
+		return false
+	}
+
+	if r.Query != nil && (e.query == "" || !r.Query.MatchString(e.query)) {
+		return false
+	}
+
+	if r.Fingerprint != "" {
+		if e.fingerprint == nil {
+			fp := FingerprintSQL(e.query, e.dialect)
+			e.fingerprint = &fp
+		}
+
+		if *e.fingerprint != r.Fingerprint {
+			return false
+		}
+	}
+
+	return true
+}
+
+// levelByRules return level of first matching rule, or given default level if none.
+// Rule without Error criteria never change the level of failed call.
+func levelByRules(rules []Rule, e *ruleEvent, def Level) Level {
+	for _, r := range rules {
+		if def == LevelError && r.Error == nil {
+			continue
+		}
+
+		if r.match(e) {
+			return r.Level
+		}
+	}
+
+	return def
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
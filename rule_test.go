@@ -0,0 +1,111 @@
+package sqldblogger
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"io"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRule_Match(t *testing.T) {
+	e := &ruleEvent{op: "QueryContext", query: "SELECT * FROM tt WHERE id = 1", connID: "conn", duration: time.Second, err: io.EOF}
+
+	tt := []struct {
+		name  string
+		rule  Rule
+		match bool
+	}{
+		{name: "Empty", rule: Rule{}, match: true},
+		{name: "Ops", rule: Rule{Ops: []string{"ExecContext", "QueryContext"}}, match: true},
+		{name: "Ops mismatch", rule: Rule{Ops: []string{"ExecContext"}}, match: false},
+		{name: "Query", rule: Rule{Query: regexp.MustCompile(`^SELECT`)}, match: true},
+		{name: "Query mismatch", rule: Rule{Query: regexp.MustCompile(`^UPDATE`)}, match: false},
+		{name: "Fingerprint", rule: Rule{Fingerprint: "select * from tt where id = ?"}, match: true},
+		{name: "Fingerprint mismatch", rule: Rule{Fingerprint: "select 1"}, match: false},
+		{name: "MinDuration", rule: Rule{MinDuration: time.Second}, match: true},
+		{name: "MinDuration mismatch", rule: Rule{MinDuration: time.Minute}, match: false},
+		{name: "Error", rule: Rule{Error: ErrorIs(io.EOF)}, match: true},
+		{name: "Error mismatch", rule: Rule{Error: ErrorIs(driver.ErrBadConn)}, match: false},
+		{name: "AnyError", rule: Rule{Error: AnyError()}, match: true},
+		{name: "ConnID", rule: Rule{ConnID: "conn"}, match: true},
+		{name: "ConnID mismatch", rule: Rule{ConnID: "other"}, match: false},
+		{name: "All", rule: Rule{Ops: []string{"QueryContext"}, ConnID: "conn", Error: AnyError(), MinDuration: time.Millisecond}, match: true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.match, tc.rule.match(e))
+		})
+	}
+
+	assert.False(t, Rule{Query: regexp.MustCompile(`.*`)}.match(&ruleEvent{op: "Ping"}))
+	assert.False(t, Rule{Error: AnyError()}.match(&ruleEvent{op: "Ping"}))
+}
+
+func TestLevelByRules(t *testing.T) {
+	rules := []Rule{
+		{Ops: []string{"Ping"}, Level: LevelTrace},
+		{Error: ErrorIs(driver.ErrBadConn), Level: LevelWarn},
+		{Level: LevelDebug},
+	}
+
+	assert.Equal(t, LevelTrace, levelByRules(rules, &ruleEvent{op: "Ping", err: driver.ErrBadConn}, LevelInfo))
+	assert.Equal(t, LevelWarn, levelByRules(rules, &ruleEvent{op: "ExecContext", err: driver.ErrBadConn}, LevelError))
+	assert.Equal(t, LevelDebug, levelByRules(rules, &ruleEvent{op: "ExecContext"}, LevelInfo))
+	assert.Equal(t, LevelInfo, levelByRules(nil, &ruleEvent{op: "ExecContext"}, LevelInfo))
+	assert.Equal(t, LevelError, levelByRules(rules, &ruleEvent{op: "ExecContext", err: io.ErrUnexpectedEOF}, LevelError))
+	assert.Equal(t, LevelError, levelByRules(rules[:1], &ruleEvent{op: "Ping", err: driver.ErrBadConn}, LevelError))
+}
+
+func TestLogInternalLevelRulesFailedCall(t *testing.T) {
+	cfg := &options{}
+	setDefaultOptions(cfg)
+	WithMinimumLevel(LevelInfo)(cfg)
+	WithLevelRules(Rule{Ops: []string{"PrepareContext"}, Level: LevelDebug})(cfg)
+
+	bl := &bufferTestLogger{}
+	l := &logger{opt: cfg, logger: bl}
+
+	l.logCall(&Call{Ctx: context.TODO(), Op: "PrepareContext", Start: time.Now(), Query: "SELECT 1"}, LevelInfo, nil)
+	assert.Empty(t, bl.Bytes())
+
+	l.logCall(&Call{Ctx: context.TODO(), Op: "PrepareContext", Start: time.Now(), Err: errors.New("dummy"), Query: "SELECT 1"}, LevelError, nil)
+
+	var content bufLog
+	assert.NoError(t, json.Unmarshal(bl.Bytes(), &content))
+	assert.Equal(t, LevelError.String(), content.Level)
+	assert.Equal(t, "dummy", content.Data[cfg.errorFieldname])
+}
+
+func TestLogInternalLevelRules(t *testing.T) {
+	cfg := &options{}
+	setDefaultOptions(cfg)
+	WithMinimumLevel(LevelInfo)(cfg)
+	WithLevelRules(
+		Rule{Query: regexp.MustCompile(`^SELECT 1$`), Level: LevelTrace},
+		Rule{Ops: []string{"ExecContext"}, ConnID: "conn", Error: AnyError(), Level: LevelWarn},
+		Rule{Level: Level(99)}, // invalid level is ignored
+	)(cfg)
+	assert.Len(t, cfg.levelRules, 2)
+
+	bl := &bufferTestLogger{}
+	l := &logger{opt: cfg, logger: bl}
+
+	l.logCall(&Call{Ctx: context.TODO(), Op: "QueryContext", Start: time.Now(), Query: "SELECT 1"}, LevelInfo, nil)
+	assert.Empty(t, bl.Bytes())
+
+	l.logCall(&Call{Ctx: context.TODO(), Op: "ExecContext", Start: time.Now(), Err: errors.New("dummy"), ConnID: "conn", Query: "DELETE FROM tt"}, LevelError, nil)
+
+	var content bufLog
+	assert.NoError(t, json.Unmarshal(bl.Bytes(), &content))
+	assert.Equal(t, LevelWarn.String(), content.Level)
+	assert.Equal(t, "dummy", content.Data[cfg.errorFieldname])
+	assert.Equal(t, "DELETE FROM tt", content.Data[cfg.sqlQueryFieldname])
+	assert.Equal(t, "conn", content.Data[cfg.connIDFieldname])
+}
@@ -0,0 +1,202 @@
+package sqldblogger
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// SampleEvent is log event information given to Sampler before it delivered to Logger.
+type SampleEvent struct {
+	// Level is final level of the log event (after slow query escalation).
+	Level Level
+	// Message is the log message, which is the operation name (e.g: "QueryContext").
+	Message string
+	// Fingerprint is the query fingerprint (see: FingerprintSQL), empty if the event has no SQL query.
+	Fingerprint string
+	// Duration is time spent on actual driver call.
+	Duration time.Duration
+	// Slow is true when duration exceed slow query threshold (see: WithSlowQueryThreshold).
+	Slow bool
+	// Err is actual driver error if any.
+	Err error
+}
+
+// key return sampling key of the event, query fingerprint if any, otherwise the message.
+func (e SampleEvent) key() string {
+	if e.Fingerprint != "" {
+		return e.Fingerprint
+	}
+
+	return e.Message
+}
+
+// Sampler decide whether a log event should be delivered to Logger (true) or sampled out (false).
+//
+// Sampler must be safe for concurrent use.
+type Sampler interface {
+	Sample(ctx context.Context, event SampleEvent) bool
+}
+
+// SamplerFunc is an adapter to use ordinary function as Sampler.
+type SamplerFunc func(ctx context.Context, event SampleEvent) bool
+
+// Sample implement Sampler.
+func (f SamplerFunc) Sample(ctx context.Context, event SampleEvent) bool { return f(ctx, event) }
+
+// NewRatioSampler create Sampler which deliver given ratio (0.0 - 1.0) of log events randomly.
+func NewRatioSampler(ratio float64) Sampler {
+	return SamplerFunc(func(_ context.Context, _ SampleEvent) bool {
+		// nolint // disable gosec check as it does not need crypto/rand
+		return ratio >= 1 || rand.Float64() < ratio
+	})
+}
+
+// NewFirstNSampler create Sampler which deliver first n log events per query fingerprint
+// (or message if no query) in every given interval.
+func NewFirstNSampler(n int, interval time.Duration) Sampler {
+	return &firstNSampler{n: n, interval: interval, counts: make(map[string]int)}
+}
+
+type firstNSampler struct {
+	mu       sync.Mutex
+	n        int
+	interval time.Duration
+	resetAt  time.Time
+	counts   map[string]int
+}
+
+// Sample implement Sampler.
+func (s *firstNSampler) Sample(_ context.Context, event SampleEvent) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now := time.Now(); !now.Before(s.resetAt) {
+		s.counts = make(map[string]int, len(s.counts))
+		s.resetAt = now.Add(s.interval)
+	}
+
+	key := event.key()
+	s.counts[key]++
+
+	return s.counts[key] <= s.n
+}
+
+// NewErrorAndSlowSampler create Sampler which always deliver error and slow query log events,
+// and delegate the rest to given sampler.
+func NewErrorAndSlowSampler(rest Sampler) Sampler {
+	return SamplerFunc(func(ctx context.Context, event SampleEvent) bool {
+		if event.Err != nil || event.Level == LevelError || event.Slow {
+			return true
+		}
+
+		return rest.Sample(ctx, event)
+	})
+}
+
+// sampledSummaryCountFieldname is fieldname of total sampled out events in sampled summary log.
+const sampledSummaryCountFieldname = "sampled_events"
+
+// sampledEvents count sampled out log events per sampling key until next summary.
+type sampledEvents struct {
+	mu     sync.Mutex
+	counts map[string]*sampledCount
+	// nextSummary is unix nano time of next summary, checked atomically on every log call.
+	nextSummary int64
+}
+
+type sampledCount struct {
+	level Level
+	count uint64
+}
+
+func newSampledEvents() *sampledEvents {
+	return &sampledEvents{counts: make(map[string]*sampledCount)}
+}
+
+// add count sampled out event, summary level is the highest level of sampled out events.
+func (s *sampledEvents) add(event SampleEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.counts[event.key()]
+	if !ok {
+		c = &sampledCount{level: event.Level}
+		s.counts[event.key()] = c
+	}
+
+	if event.Level > c.level {
+		c.level = event.Level
+	}
+
+	c.count++
+}
+
+// flush return and reset sampled out counts if summary interval elapsed.
+func (s *sampledEvents) flush(now time.Time, interval time.Duration) map[string]*sampledCount {
+	next := atomic.LoadInt64(&s.nextSummary)
+	if now.UnixNano() < next || !atomic.CompareAndSwapInt64(&s.nextSummary, next, now.Add(interval).UnixNano()) {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counts := s.counts
+	s.counts = make(map[string]*sampledCount)
+
+	return counts
+}
+
+// sample ask Sampler option whether given event should be logged, sampled out event will be counted.
+func (l *logger) sample(ctx context.Context, event SampleEvent) bool {
+	opt := l.options()
+	if opt.sampler == nil {
+		return true
+	}
+
+	l.logSampledSummary(ctx)
+
+	if opt.sampler.Sample(ctx, event) {
+		return true
+	}
+
+	opt.sampledEvents.add(event)
+
+	return false
+}
+
+// logSampledSummary log total sampled out events per sampling key once every summary interval.
+func (l *logger) logSampledSummary(ctx context.Context) {
+	opt := l.options()
+
+	now := time.Now()
+
+	el, typed := l.eventLogger()
+
+	for key, c := range opt.sampledEvents.flush(now, opt.sampledSummaryInterval) {
+		if typed {
+			el.LogEvent(ctx, &Event{
+				Op:           "Sampled",
+				Message:      "Sampled",
+				Level:        c.level,
+				Time:         now,
+				Start:        now,
+				Fingerprint:  key,
+				RowsAffected: -1,
+				Fields:       map[string]interface{}{sampledSummaryCountFieldname: c.count},
+				opt:          opt,
+			})
+
+			continue
+		}
+
+		l.logger.Log(ctx, c.level, "Sampled", map[string]interface{}{
+			opt.timeFieldname:            opt.timeFormat.format(now),
+			opt.fingerprintFieldname:     key,
+			sampledSummaryCountFieldname: c.count,
+		})
+	}
+}
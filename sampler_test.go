@@ -0,0 +1,84 @@
+package sqldblogger
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRatioSampler(t *testing.T) {
+	assert.True(t, NewRatioSampler(1).Sample(context.TODO(), SampleEvent{}))
+	assert.False(t, NewRatioSampler(0).Sample(context.TODO(), SampleEvent{}))
+}
+
+func TestNewFirstNSampler(t *testing.T) {
+	s := NewFirstNSampler(2, time.Hour)
+	a := SampleEvent{Message: "QueryContext", Fingerprint: "select ?"}
+	b := SampleEvent{Message: "Ping"}
+
+	assert.True(t, s.Sample(context.TODO(), a))
+	assert.True(t, s.Sample(context.TODO(), a))
+	assert.False(t, s.Sample(context.TODO(), a))
+	assert.True(t, s.Sample(context.TODO(), b))
+
+	t.Run("Interval Reset", func(t *testing.T) {
+		s := NewFirstNSampler(1, time.Millisecond)
+		assert.True(t, s.Sample(context.TODO(), a))
+		assert.False(t, s.Sample(context.TODO(), a))
+		time.Sleep(2 * time.Millisecond)
+		assert.True(t, s.Sample(context.TODO(), a))
+	})
+}
+
+func TestNewErrorAndSlowSampler(t *testing.T) {
+	s := NewErrorAndSlowSampler(NewRatioSampler(0))
+
+	assert.True(t, s.Sample(context.TODO(), SampleEvent{Level: LevelError}))
+	assert.True(t, s.Sample(context.TODO(), SampleEvent{Err: fmt.Errorf("dummy")}))
+	assert.True(t, s.Sample(context.TODO(), SampleEvent{Slow: true}))
+	assert.False(t, s.Sample(context.TODO(), SampleEvent{Level: LevelInfo}))
+}
+
+func TestLogInternalWithSampler(t *testing.T) {
+	cfg := &options{}
+	setDefaultOptions(cfg)
+	WithSampler(NewFirstNSampler(1, time.Hour))(cfg)
+	WithSampledSummaryInterval(time.Millisecond)(cfg)
+	bl := &sliceTestLogger{}
+	l := &logger{opt: cfg, logger: bl}
+
+	for i := 0; i < 3; i++ {
+		q := fmt.Sprintf("SELECT * FROM t WHERE id = %d", i)
+		l.logCall(&Call{Ctx: context.TODO(), Op: "QueryContext", Start: time.Now(), Query: q}, LevelInfo, nil)
+	}
+
+	assert.Len(t, bl.logs, 1)
+	assert.Equal(t, "QueryContext", bl.logs[0].Message)
+
+	time.Sleep(2 * time.Millisecond)
+	l.logCall(&Call{Ctx: context.TODO(), Op: "Ping", Start: time.Now()}, LevelDebug, nil)
+
+	assert.Len(t, bl.logs, 3)
+	assert.Equal(t, "Sampled", bl.logs[1].Message)
+	assert.Equal(t, LevelInfo.String(), bl.logs[1].Level)
+	assert.Equal(t, "select * from t where id = ?", bl.logs[1].Data[cfg.fingerprintFieldname])
+	assert.Equal(t, float64(2), bl.logs[1].Data[sampledSummaryCountFieldname])
+	assert.Equal(t, "Ping", bl.logs[2].Message)
+}
+
+// sliceTestLogger keep every log instead of only the last one.
+type sliceTestLogger struct {
+	logs []bufLog
+}
+
+func (sl *sliceTestLogger) Log(_ context.Context, level Level, msg string, data map[string]interface{}) {
+	var content bufLog
+
+	b, _ := json.Marshal(bufLog{level.String(), msg, data})
+	_ = json.Unmarshal(b, &content)
+	sl.logs = append(sl.logs, content)
+}
@@ -0,0 +1,102 @@
+package sqldblogger
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// CommentExtractor return sqlcommenter key and value from context (e.g: route, request id, W3C traceparent),
+// empty value will not be added to the SQL comment (see: WithSQLCommenter).
+type CommentExtractor func(ctx context.Context) (key, value string)
+
+// CommentFromContextValue create CommentExtractor which use context value of given context key,
+// the value must be a string or fmt.Stringer.
+func CommentFromContextValue(key string, ctxKey interface{}) CommentExtractor {
+	return func(ctx context.Context) (string, string) {
+		switch v := ctx.Value(ctxKey).(type) {
+		case string:
+			return key, v
+		case fmt.Stringer:
+			return key, v.String()
+		default:
+			return key, ""
+		}
+	}
+}
+
+// AppendSQLComment append sqlcommenter comment /*key='value',...*/ to given SQL query,
+// keys are sorted and both key and value are URL encoded.
+//
+// The comment is placed before trailing semicolon (if any).
+// Query which already has a comment, or empty comment values, will be returned as is.
+func AppendSQLComment(query string, dialect SQLDialect, values map[string]string) string {
+	if len(values) == 0 || hasSQLComment(query, dialect) {
+		return query
+	}
+
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	var b strings.Builder
+
+	trimmed := strings.TrimRight(query, " \t\r\n;")
+	b.Grow(len(query) + 16*len(keys))
+	b.WriteString(trimmed)
+	b.WriteString(" /*")
+
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		b.WriteString(url.PathEscape(k))
+		b.WriteString("='")
+		b.WriteString(url.PathEscape(values[k]))
+		b.WriteByte('\'')
+	}
+
+	b.WriteString("*/")
+	b.WriteString(query[len(trimmed):])
+
+	return b.String()
+}
+
+// hasSQLComment return true if given SQL query contain a comment.
+func hasSQLComment(query string, dialect SQLDialect) bool {
+	if !strings.Contains(query, "--") && !strings.Contains(query, "/*") && !strings.Contains(query, "#") {
+		return false
+	}
+
+	found := false
+
+	scanSQL(query, dialect, func(kind sqlTokenKind, _ string) {
+		found = found || kind == sqlTokenComment
+	})
+
+	return found
+}
+
+// withSQLComment append SQL comment from registered extractors to given query (see: WithSQLCommenter).
+func (l *logger) withSQLComment(ctx context.Context, query string) string {
+	opt := l.options()
+	if len(opt.sqlCommenters) == 0 {
+		return query
+	}
+
+	values := make(map[string]string, len(opt.sqlCommenters))
+
+	for _, extract := range opt.sqlCommenters {
+		if k, v := extract(ctx); k != "" && v != "" {
+			values[k] = v
+		}
+	}
+
+	return AppendSQLComment(query, opt.sqlDialect, values)
+}
@@ -0,0 +1,128 @@
+package sqldblogger
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type commentCtxKey struct{}
+
+type commentStringer string
+
+func (s commentStringer) String() string { return string(s) }
+
+func TestAppendSQLComment(t *testing.T) {
+	tt := []struct {
+		query  string
+		values map[string]string
+		want   string
+	}{
+		{
+			query:  "SELECT * FROM tt",
+			values: map[string]string{"route": "/param/first", "controller": "index"},
+			want:   "SELECT * FROM tt /*controller='index',route='%2Fparam%2Ffirst'*/",
+		},
+		{
+			query:  "SELECT * FROM tt;",
+			values: map[string]string{"traceparent": "00-5bd66ef5095369c7b0d1f8f4bd33716a-c532cb4098ac3dd2-01"},
+			want:   "SELECT * FROM tt /*traceparent='00-5bd66ef5095369c7b0d1f8f4bd33716a-c532cb4098ac3dd2-01'*/;",
+		},
+		{
+			query:  "SELECT 1",
+			values: map[string]string{"action's": "it's a test"},
+			want:   "SELECT 1 /*action%27s='it%27s%20a%20test'*/",
+		},
+		{
+			query:  "SELECT 1 /* existing */",
+			values: map[string]string{"route": "/"},
+			want:   "SELECT 1 /* existing */",
+		},
+		{
+			query:  "SELECT '/* not a comment */'",
+			values: map[string]string{"route": "/"},
+			want:   "SELECT '/* not a comment */' /*route='%2F'*/",
+		},
+		{
+			query: "SELECT 1",
+			want:  "SELECT 1",
+		},
+	}
+
+	for _, tc := range tt {
+		assert.Equal(t, tc.want, AppendSQLComment(tc.query, SQLDialectGeneric, tc.values))
+	}
+}
+
+func TestCommentFromContextValue(t *testing.T) {
+	extract := CommentFromContextValue("request_id", commentCtxKey{})
+
+	k, v := extract(context.WithValue(context.TODO(), commentCtxKey{}, "req-1"))
+	assert.Equal(t, "request_id", k)
+	assert.Equal(t, "req-1", v)
+
+	_, v = extract(context.WithValue(context.TODO(), commentCtxKey{}, commentStringer("req-2")))
+	assert.Equal(t, "req-2", v)
+
+	_, v = extract(context.TODO())
+	assert.Empty(t, v)
+}
+
+func TestConnection_SQLComment(t *testing.T) {
+	cfg := *testOpts
+	WithSQLCommenter(CommentFromContextValue("route", commentCtxKey{}))(&cfg)
+	l := &logger{logger: bufLogger, opt: &cfg}
+
+	q := "SELECT * FROM tt WHERE id = ?"
+	want := "SELECT * FROM tt WHERE id = ? /*route='%2Fusers'*/"
+	ctx := context.WithValue(context.TODO(), commentCtxKey{}, "/users")
+
+	driverConnMock := &driverConnCommentMock{}
+	driverConnMock.On("ExecContext", mock.Anything, want, mock.Anything).Return(driver.ResultNoRows, nil)
+	driverConnMock.On("QueryContext", mock.Anything, want, mock.Anything).Return(&rowsMock{}, nil)
+	driverConnMock.On("PrepareContext", want).Return(&statementMock{}, nil)
+
+	conn := &connection{Conn: driverConnMock, logger: l, id: "conn"}
+
+	for op, call := range map[string]func() error{
+		"ExecContext": func() error {
+			_, err := conn.ExecContext(ctx, q, nil)
+			return err
+		},
+		"QueryContext": func() error {
+			_, err := conn.QueryContext(ctx, q, nil)
+			return err
+		},
+		"PrepareContext": func() error {
+			_, err := conn.PrepareContext(ctx, q)
+			return err
+		},
+	} {
+		assert.NoError(t, call())
+
+		var output bufLog
+		assert.NoError(t, json.Unmarshal(bufLogger.Bytes(), &output))
+		assert.Equal(t, op, output.Message)
+		assert.Equal(t, want, output.Data[cfg.sqlQueryFieldname])
+	}
+
+	driverConnMock.AssertExpectations(t)
+
+	// no context value, no comment
+	_, err := conn.ExecContext(context.TODO(), want, nil)
+	assert.NoError(t, err)
+}
+
+type driverConnCommentMock struct {
+	driverConnTxMock
+}
+
+func (m *driverConnCommentMock) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	arg := m.Called(ctx, query, args)
+
+	return arg.Get(0).(driver.Rows), arg.Error(1)
+}
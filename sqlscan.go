@@ -0,0 +1,330 @@
+package sqldblogger
+
+import "strings"
+
+// SQLDialect is SQL syntax variant used when tokenizing SQL query (see: MaskSQLLiterals).
+type SQLDialect uint8
+
+const (
+	// SQLDialectGeneric is ANSI-like syntax: '...' string with '' escape, "..." and `...` quoted identifier,
+	// $tag$...$tag$ dollar-quoted string. Backslash in '...' string may or may not be an escape depending on
+	// the actual database, when both readings disagree the string is extended until both readings end at the same
+	// position, so literal of either reading is masked (with surrounding SQL masked too).
+	SQLDialectGeneric SQLDialect = iota
+	// SQLDialectMySQL is MySQL/MariaDB syntax: '...' and "..." string with backslash escape,
+	// `...` quoted identifier and # comment.
+	SQLDialectMySQL
+	// SQLDialectPostgres is PostgreSQL syntax: '...' string, E'...' string with backslash escape,
+	// "..." quoted identifier, $tag$...$tag$ dollar-quoted string and nested /* */ comment.
+	SQLDialectPostgres
+	// SQLDialectSQLite is SQLite syntax: '...' string, "...", `...` and [...] quoted identifier.
+	SQLDialectSQLite
+)
+
+// sqlDialectGenericStandard and sqlDialectGenericBackslash is SQLDialectGeneric without and with backslash escape
+// in '...' string, they are used to scan ambiguous generic string (see: scanGenericString).
+const (
+	sqlDialectGenericStandard SQLDialect = 254 + iota
+	sqlDialectGenericBackslash
+)
+
+// generic return true if the dialect is SQLDialectGeneric or one of its readings.
+func (d SQLDialect) generic() bool {
+	return d == SQLDialectGeneric || d == sqlDialectGenericStandard || d == sqlDialectGenericBackslash
+}
+
+// String implement Stringer to convert type SQLDialect to string.
+func (d SQLDialect) String() string {
+	switch d {
+	case SQLDialectMySQL:
+		return "mysql"
+	case SQLDialectPostgres:
+		return "postgresql"
+	case SQLDialectSQLite:
+		return "sqlite"
+	default:
+		return "other_sql"
+	}
+}
+
+// MaskSQLLiterals replace every string and numeric literal in given SQL query with "?".
+//
+// Quoted identifiers, comments, and placeholders (?, $1, :name, @name) are kept as is.
+func MaskSQLLiterals(query string, dialect SQLDialect) string {
+	var b strings.Builder
+
+	b.Grow(len(query))
+
+	scanSQL(query, dialect, func(kind sqlTokenKind, tok string) {
+		if kind == sqlTokenString || kind == sqlTokenNumber {
+			b.WriteByte('?')
+			return
+		}
+
+		b.WriteString(tok)
+	})
+
+	return b.String()
+}
+
+// sqlTokenKind is token type produced by scanSQL.
+type sqlTokenKind uint8
+
+const (
+	sqlTokenSpace sqlTokenKind = iota
+	sqlTokenComment
+	sqlTokenString
+	sqlTokenNumber
+	sqlTokenWord
+	sqlTokenQuotedIdent
+	sqlTokenPlaceholder
+	sqlTokenOther
+)
+
+// scanSQL split given SQL query into tokens and call fn for each token in order.
+// Concatenating all tokens will produce the original query.
+func scanSQL(query string, dialect SQLDialect, fn func(kind sqlTokenKind, tok string)) {
+	for pos := 0; pos < len(query); {
+		kind, end := scanSQLToken(query, pos, dialect)
+		fn(kind, query[pos:end])
+		pos = end
+	}
+}
+
+// scanSQLToken return token type and its end position of a token starts at pos.
+// nolint // disable gocyclo check, a tokenizer is a big switch by nature
+func scanSQLToken(q string, pos int, dialect SQLDialect) (sqlTokenKind, int) {
+	c := q[pos]
+
+	switch {
+	case isSQLSpace(c):
+		end := pos + 1
+		for end < len(q) && isSQLSpace(q[end]) {
+			end++
+		}
+
+		return sqlTokenSpace, end
+	case c == '-' && peek(q, pos+1) == '-', c == '#' && dialect == SQLDialectMySQL:
+		end := strings.IndexByte(q[pos:], '\n')
+		if end < 0 {
+			return sqlTokenComment, len(q)
+		}
+
+		return sqlTokenComment, pos + end
+	case c == '/' && peek(q, pos+1) == '*':
+		return sqlTokenComment, scanBlockComment(q, pos, dialect == SQLDialectPostgres)
+	case c == '\'':
+		return sqlTokenString, scanString(q, pos, dialect)
+	case c == '"':
+		if dialect == SQLDialectMySQL {
+			return sqlTokenString, scanQuoted(q, pos, '"', true)
+		}
+
+		return sqlTokenQuotedIdent, scanQuoted(q, pos, '"', false)
+	case c == '`' && dialect != SQLDialectPostgres:
+		return sqlTokenQuotedIdent, scanQuoted(q, pos, '`', false)
+	case c == '[' && dialect == SQLDialectSQLite:
+		end := strings.IndexByte(q[pos+1:], ']')
+		if end < 0 {
+			return sqlTokenQuotedIdent, len(q)
+		}
+
+		return sqlTokenQuotedIdent, pos + end + 2
+	case c == '$':
+		if isSQLDigit(peek(q, pos+1)) {
+			return sqlTokenPlaceholder, scanWhile(q, pos+1, isSQLDigit)
+		}
+
+		if dialect == SQLDialectPostgres || dialect.generic() {
+			if end, ok := scanDollarQuoted(q, pos); ok {
+				return sqlTokenString, end
+			}
+		}
+
+		return sqlTokenOther, pos + 1
+	case c == '?':
+		return sqlTokenPlaceholder, scanWhile(q, pos+1, isSQLDigit)
+	case (c == ':' && peek(q, pos-1) != ':' || c == '@') && isSQLIdentStart(peek(q, pos+1)):
+		return sqlTokenPlaceholder, scanWhile(q, pos+1, isSQLIdentPart)
+	case isSQLDigit(c), c == '.' && isSQLDigit(peek(q, pos+1)):
+		end := scanNumber(q, pos)
+		// MySQL identifier may start with digit (e.g: 1st_column).
+		if end < len(q) && isSQLIdentStart(q[end]) {
+			return sqlTokenWord, scanWhile(q, end, isSQLIdentPart)
+		}
+
+		return sqlTokenNumber, end
+	case isSQLIdentStart(c):
+		end := scanWhile(q, pos+1, isSQLIdentPart)
+
+		// prefixed string literal: E'...', X'...', B'...', N'...'
+		if end-pos == 1 && peek(q, end) == '\'' {
+			switch c {
+			case 'E', 'e':
+				return sqlTokenString, scanQuoted(q, end, '\'', true)
+			case 'X', 'x', 'B', 'b', 'N', 'n':
+				return sqlTokenString, scanString(q, end, dialect)
+			}
+		}
+
+		return sqlTokenWord, end
+	default:
+		return sqlTokenOther, pos + 1
+	}
+}
+
+// scanString return end position of '...' string starts at pos.
+func scanString(q string, pos int, dialect SQLDialect) int {
+	switch dialect {
+	case SQLDialectMySQL, sqlDialectGenericBackslash:
+		return scanQuoted(q, pos, '\'', true)
+	case SQLDialectGeneric:
+		return scanGenericString(q, pos)
+	default:
+		return scanQuoted(q, pos, '\'', false)
+	}
+}
+
+// scanGenericString return end position of '...' string starts at pos for unknown dialect.
+// If the string end differ with and without backslash escape (e.g: 'C:\' ...), each reading is scanned further
+// until both end at the same token boundary, so the returned string cover every literal of both readings.
+func scanGenericString(q string, pos int) int {
+	std, bs := scanQuoted(q, pos, '\'', false), scanQuoted(q, pos, '\'', true)
+
+	for std != bs {
+		if std < bs {
+			_, std = scanSQLToken(q, std, sqlDialectGenericStandard)
+		} else {
+			_, bs = scanSQLToken(q, bs, sqlDialectGenericBackslash)
+		}
+	}
+
+	return std
+}
+
+// scanQuoted return end position of quoted token starts at pos, doubled quote is an escaped quote.
+func scanQuoted(q string, pos int, quote byte, backslashEscape bool) int {
+	for i := pos + 1; i < len(q); i++ {
+		switch q[i] {
+		case '\\':
+			if backslashEscape {
+				i++
+			}
+		case quote:
+			if peek(q, i+1) != quote {
+				return i + 1
+			}
+
+			i++
+		}
+	}
+
+	return len(q)
+}
+
+// scanBlockComment return end position of /* */ comment starts at pos.
+func scanBlockComment(q string, pos int, nested bool) int {
+	depth := 0
+
+	for i := pos; i < len(q)-1; i++ {
+		switch {
+		case q[i] == '/' && q[i+1] == '*':
+			if depth == 0 || nested {
+				depth++
+			}
+
+			i++
+		case q[i] == '*' && q[i+1] == '/':
+			depth--
+			i++
+
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+
+	return len(q)
+}
+
+// scanDollarQuoted return end position of $tag$...$tag$ string starts at pos.
+func scanDollarQuoted(q string, pos int) (int, bool) {
+	tagEnd := pos + 1
+	if isSQLIdentStart(peek(q, tagEnd)) {
+		tagEnd = scanWhile(q, tagEnd, func(c byte) bool { return c != '$' && isSQLIdentPart(c) })
+	}
+
+	if peek(q, tagEnd) != '$' {
+		return 0, false
+	}
+
+	tag := q[pos : tagEnd+1]
+	end := strings.Index(q[tagEnd+1:], tag)
+
+	if end < 0 {
+		return len(q), true
+	}
+
+	return tagEnd + 1 + end + len(tag), true
+}
+
+// scanNumber return end position of numeric literal starts at pos.
+func scanNumber(q string, pos int) int {
+	if q[pos] == '0' && (peek(q, pos+1) == 'x' || peek(q, pos+1) == 'X') && isSQLHexDigit(peek(q, pos+2)) {
+		return scanWhile(q, pos+2, isSQLHexDigit)
+	}
+
+	end := scanWhile(q, pos, isSQLDigit)
+
+	if peek(q, end) == '.' {
+		end = scanWhile(q, end+1, isSQLDigit)
+	}
+
+	if e := peek(q, end); e == 'e' || e == 'E' {
+		exp := end + 1
+		if s := peek(q, exp); s == '+' || s == '-' {
+			exp++
+		}
+
+		if isSQLDigit(peek(q, exp)) {
+			end = scanWhile(q, exp, isSQLDigit)
+		}
+	}
+
+	return end
+}
+
+// scanWhile return first position from pos which given fn return false.
+func scanWhile(q string, pos int, fn func(c byte) bool) int {
+	for pos < len(q) && fn(q[pos]) {
+		pos++
+	}
+
+	return pos
+}
+
+// peek return byte at pos or 0 if pos is out of range.
+func peek(q string, pos int) byte {
+	if pos >= 0 && pos < len(q) {
+		return q[pos]
+	}
+
+	return 0
+}
+
+func isSQLSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f' || c == '\v'
+}
+
+func isSQLDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+func isSQLHexDigit(c byte) bool {
+	return isSQLDigit(c) || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+// isSQLIdentStart non-ASCII byte is considered part of identifier to keep UTF-8 identifier intact.
+func isSQLIdentStart(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c == '_' || c >= 0x80
+}
+
+func isSQLIdentPart(c byte) bool { return isSQLIdentStart(c) || isSQLDigit(c) || c == '$' }
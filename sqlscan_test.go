@@ -0,0 +1,152 @@
+package sqldblogger
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQLDialect_String(t *testing.T) {
+	tt := map[SQLDialect]string{
+		SQLDialectGeneric:  "other_sql",
+		SQLDialectMySQL:    "mysql",
+		SQLDialectPostgres: "postgresql",
+		SQLDialectSQLite:   "sqlite",
+	}
+
+	for d, s := range tt {
+		assert.Equal(t, s, d.String())
+	}
+}
+
+func TestMaskSQLLiterals(t *testing.T) {
+	tt := []struct {
+		name    string
+		dialect SQLDialect
+		query   string
+		expect  string
+	}{
+		{
+			name:   "string and number",
+			query:  "SELECT * FROM users WHERE email = 'x@y.com' AND age > 21 AND score < -1.5e3",
+			expect: "SELECT * FROM users WHERE email = ? AND age > ? AND score < -?",
+		},
+		{
+			name:   "escaped quote",
+			query:  "SELECT 'it''s', 'a' FROM t",
+			expect: "SELECT ?, ? FROM t",
+		},
+		{
+			name:   "identifier with digit",
+			query:  "SELECT t1.col_2 FROM t1 WHERE t1.id IN (1, 2, 0x1F, .5)",
+			expect: "SELECT t1.col_2 FROM t1 WHERE t1.id IN (?, ?, ?, ?)",
+		},
+		{
+			name:   "placeholders",
+			query:  "SELECT * FROM t WHERE a = ? AND b = $1 AND c = :name AND d = @p1 AND e = ?2",
+			expect: "SELECT * FROM t WHERE a = ? AND b = $1 AND c = :name AND d = @p1 AND e = ?2",
+		},
+		{
+			name:   "comments",
+			query:  "SELECT 1 -- it's 2\nFROM t /* 'secret' 3 */ WHERE a = 'b'",
+			expect: "SELECT ? -- it's 2\nFROM t /* 'secret' 3 */ WHERE a = ?",
+		},
+		{
+			name:   "generic quoted identifier",
+			query:  `SELECT "it's 1", ` + "`col 2`" + ` FROM t WHERE a = 'x'`,
+			expect: `SELECT "it's 1", ` + "`col 2`" + ` FROM t WHERE a = ?`,
+		},
+		{
+			name:   "generic ambiguous backslash escape",
+			query:  `SELECT 'a\' OR 1=1 --', 'secret'`,
+			expect: `SELECT ?`,
+		},
+		{
+			name:   "generic ambiguous backslash at string end",
+			query:  `SELECT * FROM t WHERE path = 'C:\' AND pw = 'secret123'`,
+			expect: `SELECT * FROM t WHERE path = ?`,
+		},
+		{
+			name:   "generic backslash in both readings",
+			query:  `SELECT 'a\b', 'it''s' FROM t WHERE c = 1`,
+			expect: `SELECT ?, ? FROM t WHERE c = ?`,
+		},
+		{
+			name:    "mysql backslash escape and double quoted string",
+			dialect: SQLDialectMySQL,
+			query:   `SELECT * FROM t WHERE a = 'it\'s 1' AND b = "x \" y" AND ` + "`c 1`" + ` = 2 # it's 3`,
+			expect:  `SELECT * FROM t WHERE a = ? AND b = ? AND ` + "`c 1`" + ` = ? # it's 3`,
+		},
+		{
+			name:    "mysql identifier starts with digit and hex literal",
+			dialect: SQLDialectMySQL,
+			query:   "SELECT 1st_col FROM t WHERE b = X'AB' AND c = _utf8'x'",
+			expect:  "SELECT 1st_col FROM t WHERE b = ? AND c = _utf8?",
+		},
+		{
+			name:    "postgres standard string with backslash",
+			dialect: SQLDialectPostgres,
+			query:   `SELECT 'C:\', E'it\'s', "col 1" FROM t WHERE a::text = 'b'`,
+			expect:  `SELECT ?, ?, "col 1" FROM t WHERE a::text = ?`,
+		},
+		{
+			name:    "postgres dollar quoted string",
+			dialect: SQLDialectPostgres,
+			query:   "SELECT $$it's 1$$, $tag$ $$ 'x' $tag$, $1 FROM t",
+			expect:  "SELECT ?, ?, $1 FROM t",
+		},
+		{
+			name:    "postgres nested comment",
+			dialect: SQLDialectPostgres,
+			query:   "SELECT /* a /* 'b' */ 1 */ 2",
+			expect:  "SELECT /* a /* 'b' */ 1 */ ?",
+		},
+		{
+			name:    "sqlite bracket identifier",
+			dialect: SQLDialectSQLite,
+			query:   "SELECT [col 'x' 1], \"col 2\" FROM t WHERE a = 'b' AND c = 3",
+			expect:  "SELECT [col 'x' 1], \"col 2\" FROM t WHERE a = ? AND c = ?",
+		},
+		{
+			name:   "unterminated string",
+			query:  "SELECT * FROM t WHERE a = 'secret",
+			expect: "SELECT * FROM t WHERE a = ?",
+		},
+		{
+			name:   "utf-8 identifier",
+			query:  "SELECT naïve1 FROM t WHERE ñ = 'é'",
+			expect: "SELECT naïve1 FROM t WHERE ñ = ?",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expect, MaskSQLLiterals(tc.query, tc.dialect))
+		})
+	}
+}
+
+func TestScanSQL(t *testing.T) {
+	q := "SELECT a, 'b' FROM t /* c */ WHERE d = $1 AND e = 2"
+
+	var (
+		b     strings.Builder
+		kinds []sqlTokenKind
+	)
+
+	scanSQL(q, SQLDialectPostgres, func(kind sqlTokenKind, tok string) {
+		b.WriteString(tok)
+
+		if kind != sqlTokenSpace {
+			kinds = append(kinds, kind)
+		}
+	})
+
+	assert.Equal(t, q, b.String())
+	assert.Equal(t, []sqlTokenKind{
+		sqlTokenWord, sqlTokenWord, sqlTokenOther, sqlTokenString, sqlTokenWord, sqlTokenWord, sqlTokenComment,
+		sqlTokenWord, sqlTokenWord, sqlTokenOther, sqlTokenPlaceholder, sqlTokenWord, sqlTokenWord, sqlTokenOther,
+		sqlTokenNumber,
+	}, kinds)
+}
@@ -14,22 +14,33 @@ import (
 // - driver.ColumnConverter
 type statement struct {
 	driver.Stmt
+	// ctx is context of Prepare(Context) call, used to log statement call without context.
+	ctx    context.Context
 	query  string
 	logger *logger
 	id     string
 	connID string
+	// conn is connection of the statement, its active transaction is looked up on every call
+	// because database/sql reuse statement prepared on the connection for transaction (see: sql.Tx.Stmt).
+	conn *connection
+	// leak is leak detector tracker, nil if disabled (see: WithLeakDetection).
+	leak *leakTracker
 }
 
 // Close implements driver.Stmt
 func (s *statement) Close() error {
-	lvl, start := LevelDebug, time.Now()
-	err := s.Stmt.Close()
+	s.leak.close()
 
+	lvl := LevelDebug
+	call := s.call(s.ctx, "StmtClose", nil)
+	defer call.release()
+
+	err := s.logger.intercept(call)
 	if err != nil {
 		lvl = LevelError
 	}
 
-	s.logger.log(context.Background(), lvl, "StmtClose", start, err, s.logData()...)
+	s.logger.logCall(call, lvl, nil)
 
 	return err
 }
@@ -41,74 +52,78 @@ func (s *statement) NumInput() int {
 
 // Exec implements driver.Stmt
 func (s *statement) Exec(args []driver.Value) (driver.Result, error) {
-	logs := append(s.logData(), s.logger.withArgs(args))
-	lvl, start := s.logger.opt.execerLevel, time.Now()
-	res, err := s.Stmt.Exec(args) // nolint // disable static check on deprecated driver method
+	lvl := s.logger.options().execerLevel
+	call := s.call(s.ctx, "StmtExec", valuesToNamedValues(args))
+	defer call.release()
 
+	err := s.logger.intercept(call)
 	if err != nil {
 		lvl = LevelError
 	}
 
-	s.logger.log(context.Background(), lvl, "StmtExec", start, err, logs...)
+	s.tx().record(call.Result, err)
+	s.logger.logCall(call, lvl, nil)
 
-	return s.result(res, err, args)
+	return s.result(s.ctx, call.Result, err, call.Args)
 }
 
 // Query implements driver.Stmt
 func (s *statement) Query(args []driver.Value) (driver.Rows, error) {
-	logs := append(s.logData(), s.logger.withArgs(args))
-	lvl, start := s.logger.opt.queryerLevel, time.Now()
-	res, err := s.Stmt.Query(args) // nolint // disable static check on deprecated driver method
+	lvl := s.logger.options().queryerLevel
+	call := s.call(s.ctx, "StmtQuery", valuesToNamedValues(args))
+	defer call.release()
 
+	err := s.logger.intercept(call)
 	if err != nil {
 		lvl = LevelError
 	}
 
-	s.logger.log(context.Background(), lvl, "StmtQuery", start, err, logs...)
+	s.tx().record(nil, err)
+	s.logger.logCall(call, lvl, nil)
 
-	return s.rows(res, err, args)
+	return s.rows(s.ctx, call.Rows, err, call.Args)
 }
 
 // ExecContext implements driver.StmtExecContext
 func (s *statement) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
-	stmtExecer, ok := s.Stmt.(driver.StmtExecContext)
-	if !ok {
+	if _, ok := s.Stmt.(driver.StmtExecContext); !ok {
 		return nil, driver.ErrSkip
 	}
 
-	logArgs := namedValuesToValues(args)
-	logs := append(s.logData(), s.logger.withArgs(logArgs))
-	lvl, start := s.logger.opt.execerLevel, time.Now()
-	res, err := stmtExecer.ExecContext(ctx, args)
+	lvl := s.logger.options().execerLevel
+	call := s.call(ctx, "StmtExecContext", args)
+	defer call.release()
 
+	err := s.logger.intercept(call)
 	if err != nil {
 		lvl = LevelError
 	}
 
-	s.logger.log(ctx, lvl, "StmtExecContext", start, err, logs...)
+	s.tx().record(call.Result, err)
+	s.logger.logCall(call, lvl, nil)
 
-	return s.result(res, err, logArgs)
+	return s.result(ctx, call.Result, err, call.Args)
 }
 
 // QueryContext implements driver.StmtQueryContext
 func (s *statement) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
-	stmtQueryer, ok := s.Stmt.(driver.StmtQueryContext)
-	if !ok {
+	if _, ok := s.Stmt.(driver.StmtQueryContext); !ok {
 		return nil, driver.ErrSkip
 	}
 
-	logArgs := namedValuesToValues(args)
-	logs := append(s.logData(), s.logger.withArgs(logArgs))
-	lvl, start := s.logger.opt.queryerLevel, time.Now()
-	res, err := stmtQueryer.QueryContext(ctx, args)
+	lvl := s.logger.options().queryerLevel
+	call := s.call(ctx, "StmtQueryContext", args)
+	defer call.release()
 
+	err := s.logger.intercept(call)
 	if err != nil {
 		lvl = LevelError
 	}
 
-	s.logger.log(ctx, lvl, "StmtQueryContext", start, err, logs...)
+	s.tx().record(nil, err)
+	s.logger.logCall(call, lvl, nil)
 
-	return s.rows(res, err, logArgs)
+	return s.rows(ctx, call.Rows, err, call.Args)
 }
 
 // CheckNamedValue implements driver.NamedValueChecker
@@ -118,16 +133,21 @@ func (s *statement) CheckNamedValue(nm *driver.NamedValue) error {
 		return driver.ErrSkip
 	}
 
-	lvl, start := LevelTrace, time.Now()
-	err := checker.CheckNamedValue(nm)
+	// argument check is not intercepted, the call only carry log data.
+	lvl := LevelTrace
+	call := s.call(s.ctx, "StmtCheckNamedValue", nil)
+	defer call.release()
 
-	if err != nil {
+	call.Start = time.Now()
+	call.Err = checker.CheckNamedValue(nm)
+
+	if call.Err != nil {
 		lvl = LevelError
 	}
 
-	s.logger.log(context.Background(), lvl, "StmtCheckNamedValue", start, err, s.logData()...)
+	s.logger.logCall(call, lvl, nil)
 
-	return err
+	return call.Err
 }
 
 // ColumnConverter implements driver.ColumnConverter
@@ -140,27 +160,63 @@ func (s *statement) ColumnConverter(idx int) driver.ValueConverter {
 	return driver.DefaultParameterConverter
 }
 
-func (s *statement) rows(res driver.Rows, err error, args []driver.Value) (driver.Rows, error) {
-	if !s.logger.opt.wrapResult || err != nil {
+func (s *statement) rows(ctx context.Context, res driver.Rows, err error, args []driver.NamedValue) (driver.Rows, error) {
+	if !s.logger.options().wrapResult || err != nil {
 		return res, err
 	}
 
-	return &rows{Rows: res, logger: s.logger, connID: s.connID, stmtID: s.id, query: s.query, args: args}, nil
+	r := &rows{Rows: res, ctx: ctx, logger: s.logger, connID: s.connID, txID: s.tx().uid(), stmtID: s.id, query: s.query, args: args, openedAt: time.Now()}
+	r.leak = s.logger.trackLeak(r, "RowsLeak", true)
+
+	return r, nil
 }
 
-func (s *statement) result(res driver.Result, err error, args []driver.Value) (driver.Result, error) {
-	if !s.logger.opt.wrapResult || err != nil {
+func (s *statement) result(ctx context.Context, res driver.Result, err error, args []driver.NamedValue) (driver.Result, error) {
+	if !s.logger.options().wrapResult || err != nil {
 		return res, err
 	}
 
-	return &result{Result: res, logger: s.logger, connID: s.connID, stmtID: s.id, query: s.query, args: args}, nil
+	return &result{Result: res, ctx: ctx, logger: s.logger, connID: s.connID, txID: s.tx().uid(), stmtID: s.id, query: s.query, args: args}, nil
+}
+
+// tx return active transaction of the statement connection, nil if none.
+func (s *statement) tx() *transaction {
+	if s.conn == nil {
+		return nil
+	}
+
+	return s.conn.tx
+}
+
+// call get interceptor call descriptor for statement call.
+func (s *statement) call(ctx context.Context, op string, args []driver.NamedValue) *Call {
+	call := s.logger.newCall(ctx, op, s)
+	call.Query, call.Args = s.query, args
+	call.ConnID, call.StmtID, call.TxID = s.connID, s.id, s.tx().uid()
+
+	return call
 }
 
-// logData default log data for statement log.
-func (s *statement) logData() []dataFunc {
-	return []dataFunc{
-		s.logger.withUID(s.logger.opt.connIDFieldname, s.connID),
-		s.logger.withUID(s.logger.opt.stmtIDFieldname, s.id),
-		s.logger.withQuery(s.query),
+// invoke implements invoker, it do the actual driver call.
+// nolint // disable static check on deprecated driver method
+func (s *statement) invoke(call *Call) (err error) {
+	switch call.Op {
+	case "StmtClose":
+		err = s.Stmt.Close()
+	case "StmtExec":
+		call.Result, err = s.Stmt.Exec(namedValuesToValues(call.Args))
+	case "StmtQuery":
+		call.Rows, err = s.Stmt.Query(namedValuesToValues(call.Args))
+	case "StmtExecContext":
+		call.Result, err = s.Stmt.(driver.StmtExecContext).ExecContext(call.Ctx, call.Args)
+	case "StmtQueryContext":
+		call.Rows, err = s.Stmt.(driver.StmtQueryContext).QueryContext(call.Ctx, call.Args)
 	}
+
+	return err
+}
+
+// leakData implements leakObject.
+func (s *statement) leakData(t *leakTracker) {
+	t.connID, t.txID, t.stmtID, t.query = s.connID, s.tx().uid(), s.id, s.query
 }
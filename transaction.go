@@ -6,45 +6,126 @@ import (
 	"time"
 )
 
+// transaction is a wrapper which log transaction summary (duration since begin, total statements,
+// total rows affected and whether any statement error) on Commit() and Rollback().
 type transaction struct {
 	driver.Tx
+	// ctx is context of Begin(Tx) call, used to log Commit() and Rollback().
+	ctx    context.Context
 	id     string
 	connID string
 	logger *logger
+	conn   *connection
+	// begin is time when transaction started.
+	begin        time.Time
+	statements   int64
+	rowsAffected int64
+	hadError     bool
+	// unwatch stop tracking this open transaction by watchdog.
+	unwatch func()
+	// leak is leak detector tracker, nil if disabled (see: WithLeakDetection).
+	leak *leakTracker
 }
 
 // Commit implement driver.Tx
 func (tx *transaction) Commit() error {
-	lvl, start := LevelDebug, time.Now()
-	err := tx.Tx.Commit()
+	return tx.end("Commit")
+}
+
+// Rollback implement driver.Tx
+func (tx *transaction) Rollback() error {
+	return tx.end("Rollback")
+}
+
+// end commit or rollback the transaction by given operation, then log transaction summary.
+func (tx *transaction) end(op string) error {
+	lvl := LevelDebug
+	call := tx.logger.newCall(tx.ctx, op, tx)
+	defer call.release()
+
+	call.ConnID, call.TxID = tx.connID, tx.id
+
+	err := tx.logger.intercept(call)
+	tx.done()
 
 	if err != nil {
 		lvl = LevelError
 	}
 
-	tx.logger.log(context.Background(), lvl, "Commit", start, err, tx.logData()...)
+	tx.logger.logCall(call, lvl, tx)
 
 	return err
 }
 
-// Rollback implement driver.Tx
-func (tx *transaction) Rollback() error {
-	lvl, start := LevelDebug, time.Now()
-	err := tx.Tx.Rollback()
+// invoke implements invoker, it do the actual driver call.
+func (tx *transaction) invoke(call *Call) error {
+	if call.Op == "Commit" {
+		return tx.Tx.Commit()
+	}
+
+	return tx.Tx.Rollback()
+}
+
+// record statement executed under this transaction for transaction summary.
+// It is safe to call on nil transaction (no active transaction).
+func (tx *transaction) record(res driver.Result, err error) {
+	if tx == nil || err == driver.ErrSkip {
+		return
+	}
+
+	tx.statements++
 
 	if err != nil {
-		lvl = LevelError
+		tx.hadError = true
+		return
 	}
 
-	tx.logger.log(context.Background(), lvl, "Rollback", start, err, tx.logData()...)
+	if res == nil {
+		return
+	}
 
-	return err
+	if num, err := res.RowsAffected(); err == nil {
+		tx.rowsAffected += num
+	}
 }
 
-// logData default log data for transaction.
-func (tx *transaction) logData() []dataFunc {
-	return []dataFunc{
-		tx.logger.withUID(tx.logger.opt.connIDFieldname, tx.connID),
-		tx.logger.withUID(tx.logger.opt.txIDFieldname, tx.id),
+// uid return transaction id, empty if nil transaction (no active transaction).
+func (tx *transaction) uid() string {
+	if tx == nil {
+		return ""
 	}
+
+	return tx.id
+}
+
+// eventFields implements eventFielder, it add transaction summary to Commit() and Rollback() log.
+func (tx *transaction) eventFields(e *Event, _ *Call) {
+	fields := e.fields()
+
+	if !tx.begin.IsZero() {
+		fields["tx_duration"] = time.Since(tx.begin)
+	}
+
+	fields["tx_statements"] = tx.statements
+	fields["tx_rows_affected"] = tx.rowsAffected
+	fields["tx_had_error"] = tx.hadError
+	e.RowsAffected = tx.rowsAffected
+}
+
+// done detach this transaction from its connection, so next call on that connection no longer has tx id.
+func (tx *transaction) done() {
+	tx.leak.close()
+
+	if tx.unwatch != nil {
+		tx.unwatch()
+	}
+
+	if tx.conn != nil && tx.conn.tx == tx {
+		tx.conn.tx = nil
+	}
+}
+
+// leakData implements leakObject.
+func (tx *transaction) leakData(t *leakTracker) {
+	t.connID, t.txID = tx.connID, tx.id
 }
@@ -1,9 +1,11 @@
 package sqldblogger
 
 import (
+	"context"
 	"database/sql/driver"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -73,6 +75,34 @@ func TestTransaction_Rollback(t *testing.T) {
 	})
 }
 
+func TestTransaction_Summary(t *testing.T) {
+	txMock := &transactionMock{}
+	txMock.On("Rollback").Return(nil)
+
+	tx := &transaction{Tx: txMock, logger: testLogger, id: testLogger.opt.uidGenerator.UniqueID(), begin: time.Now()}
+	tx.record(driver.RowsAffected(3), nil)
+	tx.record(driver.ResultNoRows, nil)
+	tx.record(nil, nil)
+	tx.record(nil, driver.ErrSkip)
+	tx.record(nil, driver.ErrBadConn)
+	assert.NoError(t, tx.Rollback())
+
+	var output bufLog
+	err := json.Unmarshal(bufLogger.Bytes(), &output)
+	assert.NoError(t, err)
+	assert.Equal(t, "Rollback", output.Message)
+	assert.Contains(t, output.Data, "tx_duration")
+	assert.Equal(t, float64(4), output.Data["tx_statements"])
+	assert.Equal(t, float64(3), output.Data["tx_rows_affected"])
+	assert.Equal(t, true, output.Data["tx_had_error"])
+
+	t.Run("Nil Transaction", func(t *testing.T) {
+		var nilTx *transaction
+		nilTx.record(driver.RowsAffected(1), nil)
+		assert.Equal(t, "", nilTx.uid())
+	})
+}
+
 type transactionMock struct {
 	mock.Mock
 }
@@ -84,3 +114,82 @@ func (m *transactionMock) Commit() error {
 func (m *transactionMock) Rollback() error {
 	return m.Called().Error(0)
 }
+
+func TestTransaction_StmtPreparedOnDB(t *testing.T) {
+	sl := &syncTestLogger{}
+	db := OpenConnector(txStmtConnector{}, sl, WithMinimumLevel(LevelTrace))
+	defer db.Close()
+
+	// single connection so transaction reuse statement prepared on it
+	db.SetMaxOpenConns(1)
+
+	st, err := db.Prepare("UPDATE tt SET a = 1")
+	assert.NoError(t, err)
+
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	res, err := tx.Stmt(st).Exec()
+	assert.NoError(t, err)
+
+	num, err := res.RowsAffected()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), num)
+	assert.NoError(t, tx.Commit())
+	assert.NoError(t, st.Close())
+
+	var txID interface{}
+
+	seen := map[string]bool{}
+
+	for _, lg := range sl.get() {
+		seen[lg.msg] = true
+
+		switch lg.msg {
+		case "BeginTx":
+			txID = lg.data[testOpts.txIDFieldname]
+		case "StmtExecContext":
+			assert.NotEmpty(t, txID)
+			assert.Equal(t, txID, lg.data[testOpts.txIDFieldname])
+		case "Commit":
+			assert.Equal(t, int64(1), lg.data["tx_statements"])
+			assert.Equal(t, int64(3), lg.data["tx_rows_affected"])
+		}
+	}
+
+	assert.True(t, seen["StmtExecContext"])
+	assert.True(t, seen["Commit"])
+}
+
+// txStmtConnector open connection which statement affect 3 rows.
+type txStmtConnector struct{}
+
+func (txStmtConnector) Connect(_ context.Context) (driver.Conn, error) { return txStmtConn{}, nil }
+func (txStmtConnector) Driver() driver.Driver                          { return nil }
+
+type txStmtConn struct{}
+
+func (txStmtConn) Prepare(_ string) (driver.Stmt, error) { return txStmtStmt{}, nil }
+func (txStmtConn) PrepareContext(_ context.Context, _ string) (driver.Stmt, error) {
+	return txStmtStmt{}, nil
+}
+func (txStmtConn) Close() error              { return nil }
+func (txStmtConn) Begin() (driver.Tx, error) { return txStmtTx{}, nil }
+func (txStmtConn) BeginTx(_ context.Context, _ driver.TxOptions) (driver.Tx, error) {
+	return txStmtTx{}, nil
+}
+
+type txStmtStmt struct{}
+
+func (txStmtStmt) Close() error                                 { return nil }
+func (txStmtStmt) NumInput() int                                { return -1 }
+func (txStmtStmt) Exec(_ []driver.Value) (driver.Result, error) { return driver.RowsAffected(3), nil }
+func (txStmtStmt) Query(_ []driver.Value) (driver.Rows, error)  { return nil, driver.ErrSkip }
+func (txStmtStmt) ExecContext(_ context.Context, _ []driver.NamedValue) (driver.Result, error) {
+	return driver.RowsAffected(3), nil
+}
+
+type txStmtTx struct{}
+
+func (txStmtTx) Commit() error   { return nil }
+func (txStmtTx) Rollback() error { return nil }
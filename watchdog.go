@@ -0,0 +1,182 @@
+package sqldblogger
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// watchdogLevel is level of long running call and transaction log.
+const watchdogLevel = LevelWarn
+
+// watchdogMinTick is minimum check period of watchdog worker, so tiny threshold or interval does not spin it.
+const watchdogMinTick = time.Millisecond
+
+// watchdog log in-flight driver calls and open transactions which exceed threshold age,
+// repeated every interval until finished (see: WithWatchdog).
+type watchdog struct {
+	logger    *logger
+	threshold time.Duration
+	interval  time.Duration
+	mu        sync.Mutex
+	nextID    uint64
+	inflight  map[uint64]*watchItem
+	startOnce sync.Once
+	stopOnce  sync.Once
+	stop      chan struct{}
+}
+
+// watchItem is a tracked in-flight driver call or open transaction.
+type watchItem struct {
+	op       string
+	connID   string
+	txID     string
+	stmtID   string
+	query    string
+	start    time.Time
+	lastWarn time.Time
+}
+
+func newWatchdog(l *logger, threshold, interval time.Duration) *watchdog {
+	return &watchdog{
+		logger:    l,
+		threshold: threshold,
+		interval:  interval,
+		inflight:  make(map[uint64]*watchItem),
+		stop:      make(chan struct{}),
+	}
+}
+
+// noopUnwatch is returned by watch() when watchdog is disabled.
+func noopUnwatch() {}
+
+// watch track given call until returned func called, background worker started on first call.
+// It is safe to call on nil watchdog (disabled).
+func (w *watchdog) watch(op, connID, txID, stmtID, query string) func() {
+	if w == nil {
+		return noopUnwatch
+	}
+
+	w.startOnce.Do(func() { go w.run() })
+
+	w.mu.Lock()
+	w.nextID++
+	id := w.nextID
+	w.inflight[id] = &watchItem{op: op, connID: connID, txID: txID, stmtID: stmtID, query: query, start: time.Now()}
+	w.mu.Unlock()
+
+	return func() {
+		w.mu.Lock()
+		delete(w.inflight, id)
+		w.mu.Unlock()
+	}
+}
+
+// close stop background worker.
+// It is safe to call on nil watchdog (disabled).
+func (w *watchdog) close() {
+	if w == nil {
+		return
+	}
+
+	w.stopOnce.Do(func() { close(w.stop) })
+}
+
+func (w *watchdog) run() {
+	period := w.threshold
+	if w.interval < period {
+		period = w.interval
+	}
+
+	period /= 2
+	if period < watchdogMinTick {
+		period = watchdogMinTick
+	}
+
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		case now := <-ticker.C:
+			for _, item := range w.expired(now) {
+				w.log(now, item)
+			}
+		}
+	}
+}
+
+// expired return copy of in-flight items which exceed threshold and not warned in last interval.
+func (w *watchdog) expired(now time.Time) []watchItem {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var items []watchItem
+
+	for _, item := range w.inflight {
+		if now.Sub(item.start) < w.threshold || (!item.lastWarn.IsZero() && now.Sub(item.lastWarn) < w.interval) {
+			continue
+		}
+
+		item.lastWarn = now
+		items = append(items, *item)
+	}
+
+	return items
+}
+
+func (w *watchdog) log(now time.Time, item watchItem) {
+	l := w.logger
+	opt := l.options()
+
+	if watchdogLevel < opt.minimumLogLevel {
+		return
+	}
+
+	if le, ok := l.levelEnabler(); ok && !le.Enabled(context.Background(), watchdogLevel) {
+		return
+	}
+
+	if el, ok := l.eventLogger(); ok {
+		e := &Event{
+			Op:           item.op,
+			Message:      item.op,
+			Level:        watchdogLevel,
+			Time:         now,
+			Start:        item.start,
+			Duration:     now.Sub(item.start),
+			ConnID:       item.connID,
+			StmtID:       item.stmtID,
+			TxID:         item.txID,
+			RowsAffected: -1,
+			Query:        maskQuery(opt, item.query),
+			Fields:       map[string]interface{}{"in_flight": true},
+			opt:          opt,
+		}
+
+		el.LogEvent(context.Background(), e)
+
+		return
+	}
+
+	data := map[string]interface{}{
+		opt.timeFieldname: opt.timeFormat.format(now),
+		"elapsed":         opt.durationUnit.format(now.Sub(item.start)),
+		"in_flight":       true,
+	}
+
+	for k, v := range map[string]string{
+		opt.connIDFieldname:   item.connID,
+		opt.txIDFieldname:     item.txID,
+		opt.stmtIDFieldname:   item.stmtID,
+		opt.sqlQueryFieldname: maskQuery(opt, item.query),
+	} {
+		if v != "" {
+			data[k] = v
+		}
+	}
+
+	l.logger.Log(context.Background(), watchdogLevel, item.op, data)
+}
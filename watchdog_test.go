@@ -0,0 +1,122 @@
+package sqldblogger
+
+import (
+	"context"
+	"database/sql/driver"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWatchdog(t *testing.T) {
+	bl := &syncTestLogger{}
+	l := newLogger(bl, WithWatchdog(10*time.Millisecond, 20*time.Millisecond))
+	defer l.watchdog.close()
+
+	unwatch := l.watch("QueryContext", "conn", "tx", "", "SELECT 1")
+	time.Sleep(60 * time.Millisecond)
+	unwatch()
+
+	logs := bl.get()
+	assert.GreaterOrEqual(t, len(logs), 2)
+	assert.Equal(t, "QueryContext", logs[0].msg)
+	assert.Equal(t, LevelWarn, logs[0].level)
+	assert.Equal(t, "conn", logs[0].data[l.opt.connIDFieldname])
+	assert.Equal(t, "tx", logs[0].data[l.opt.txIDFieldname])
+	assert.Equal(t, "SELECT 1", logs[0].data[l.opt.sqlQueryFieldname])
+	assert.Equal(t, true, logs[0].data["in_flight"])
+	assert.Contains(t, logs[0].data, "elapsed")
+	assert.NotContains(t, logs[0].data, l.opt.stmtIDFieldname)
+
+	// finished call no longer logged
+	total := len(bl.get())
+	time.Sleep(40 * time.Millisecond)
+	assert.Equal(t, total, len(bl.get()))
+}
+
+func TestWatchdog_Transaction(t *testing.T) {
+	bl := &syncTestLogger{}
+	l := newLogger(bl, WithWatchdog(5*time.Millisecond, 0))
+	defer l.watchdog.close()
+
+	driverConnMock := &driverConnWithContextMock{}
+	txMock := &transactionMock{}
+	driverConnMock.On("BeginTx", mock.Anything, mock.Anything).Return(txMock, nil)
+	txMock.On("Commit").Return(nil)
+
+	conn := &connection{Conn: driverConnMock, logger: l, id: "conn"}
+	tx, err := conn.BeginTx(context.TODO(), driver.TxOptions{})
+	assert.NoError(t, err)
+	time.Sleep(30 * time.Millisecond)
+	assert.NoError(t, tx.Commit())
+
+	var found bool
+
+	for _, lg := range bl.get() {
+		if lg.msg == "Transaction" {
+			found = true
+			assert.NotEmpty(t, lg.data[l.opt.txIDFieldname])
+		}
+	}
+
+	assert.True(t, found)
+	assert.Equal(t, 0, l.watchdog.inflightLen())
+}
+
+func TestWatchdog_TinyThreshold(t *testing.T) {
+	bl := &syncTestLogger{}
+	l := newLogger(bl, WithWatchdog(1, 0))
+	defer l.watchdog.close()
+
+	unwatch := l.watch("Ping", "conn", "", "", "")
+	assert.Eventually(t, func() bool { return len(bl.get()) > 0 }, time.Second, 5*time.Millisecond)
+	unwatch()
+
+	assert.Equal(t, "Ping", bl.get()[0].msg)
+}
+
+func TestWatchdog_Disabled(t *testing.T) {
+	var w *watchdog
+	unwatch := w.watch("Ping", "conn", "", "", "")
+	unwatch()
+	w.close()
+
+	l := newLogger(&syncTestLogger{})
+	assert.Nil(t, l.watchdog)
+}
+
+func (w *watchdog) inflightLen() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return len(w.inflight)
+}
+
+// syncTestLogger keep every log and safe for concurrent use.
+type syncTestLogger struct {
+	mu   sync.Mutex
+	logs []syncTestLog
+}
+
+type syncTestLog struct {
+	level Level
+	msg   string
+	data  map[string]interface{}
+}
+
+func (sl *syncTestLogger) Log(_ context.Context, level Level, msg string, data map[string]interface{}) {
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+
+	sl.logs = append(sl.logs, syncTestLog{level: level, msg: msg, data: data})
+}
+
+func (sl *syncTestLogger) get() []syncTestLog {
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+
+	return append([]syncTestLog(nil), sl.logs...)
+}